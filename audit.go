@@ -0,0 +1,43 @@
+package journalskill
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	alexa "github.com/petergtz/go-alexa"
+)
+
+// AuditTrail writes a JSON line for every entry that was added, deleted or edited, so that it's possible to tell
+// afterwards who changed the journal when.
+type AuditTrail struct {
+	Writer io.Writer
+
+	mutex sync.Mutex
+}
+
+type auditRecord struct {
+	Timestamp time.Time    `json:"timestamp"`
+	UserID    string       `json:"userId"`
+	Action    MutationKind `json:"action"`
+	EntryID   string       `json:"entryId"`
+	Intent    string       `json:"intent"`
+}
+
+func (at *AuditTrail) InterceptResponse(in *Input, responseEnv *alexa.ResponseEnvelope) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	for _, mutation := range in.Mutations {
+		e := json.NewEncoder(at.Writer).Encode(auditRecord{
+			Timestamp: mutation.Time.UTC(),
+			UserID:    in.userID(),
+			Action:    mutation.Kind,
+			EntryID:   mutation.EntryID,
+			Intent:    in.intentName(),
+		})
+		if e != nil {
+			in.Log.Errorw("Could not write audit record", "error", e, "mutation", mutation)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command export exports a journal to Markdown, HTML or JSON.
+//
+// The journal is read either from a local TSV file or from the Google Sheet the skill writes to:
+//
+//	export -tsv journal.tsv -format markdown -o journal.md
+//	GOOGLE_DRIVE_TOKEN=... export -sheet Journal -format html -o journal.html
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/petergtz/alexa-journal/cmd/internal/cli"
+	"github.com/petergtz/alexa-journal/export"
+)
+
+func main() {
+	var journalFlags cli.JournalFlags
+	journalFlags.Register(flag.CommandLine)
+	formatName := flag.String("format", "markdown", "Export format: markdown, html or json")
+	outputPath := flag.String("o", "", "Output file. Defaults to stdout")
+	title := flag.String("title", "Journal", "Title of the exported document")
+	language := flag.String("lang", "en", "Language of month names: en or de")
+	flag.Parse()
+
+	format, ok := export.FormatFrom(*formatName)
+	if !ok {
+		cli.ExitWithError(fmt.Errorf("Unknown format %v", *formatName))
+	}
+
+	ctx := context.Background()
+	journal, e := journalFlags.Journal(ctx, true)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	entries, e := journal.GetEntries(ctx, "")
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	content, e := export.Export(entries, format, export.Options{Title: *title, Language: *language})
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+
+	if *outputPath == "" {
+		fmt.Print(content)
+		return
+	}
+	if e := ioutil.WriteFile(*outputPath, []byte(content), 0644); e != nil {
+		cli.ExitWithError(e)
+	}
+}
@@ -0,0 +1,99 @@
+// Command import imports entries from other journaling apps into a journal.
+//
+// Supported are Day One JSON exports, Journey exports (one JSON file per entry), generic CSV files
+// and journals in the skill's own TSV format:
+//
+//	import -tsv journal.tsv -format dayone Journal.json
+//	import -tsv journal.tsv -format journey journey-export/*.json
+//	import -tsv journal.tsv -format csv -date-column 0 -text-column 2 -skip-header entries.csv
+//	GOOGLE_DRIVE_TOKEN=... import -sheet Journal -format tsv old-journal.tsv
+//
+// Entries which exist already with the same date and text are skipped. The journal must exist, unless -create is
+// given.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/petergtz/alexa-journal/cmd/internal/cli"
+	"github.com/petergtz/alexa-journal/importer"
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+func main() {
+	var journalFlags cli.JournalFlags
+	journalFlags.Register(flag.CommandLine)
+	flag.BoolVar(&journalFlags.Create, "create", false, "Create the journal if it doesn't exist")
+	format := flag.String("format", "", "Format of the input files: dayone, journey, csv or tsv")
+	batchSize := flag.Int("batch-size", 500, "Number of rows appended to the journal at once")
+	dateColumn := flag.Int("date-column", 0, "CSV only: column of the entry date, starting at 0")
+	textColumn := flag.Int("text-column", 1, "CSV only: column of the entry text, starting at 0")
+	tagsColumn := flag.Int("tags-column", -1, "CSV only: column of comma-separated tags, or -1 for none")
+	dateFormat := flag.String("date-format", "", "CSV only: date layout as understood by Go's time.Parse, e.g. 01/02/2006")
+	delimiter := flag.String("delimiter", ",", "CSV only: field delimiter")
+	skipHeader := flag.Bool("skip-header", false, "CSV only: skip the first row")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		cli.ExitWithError(fmt.Errorf("Please specify at least one file to import"))
+	}
+	comma, _ := utf8.DecodeRuneInString(*delimiter)
+	csvColumns := importer.CSVColumns{
+		Date:       *dateColumn,
+		Text:       *textColumn,
+		Tags:       *tagsColumn,
+		DateFormat: *dateFormat,
+		Comma:      comma,
+		SkipHeader: *skipHeader,
+	}
+
+	var entries []j.Entry
+	for _, path := range flag.Args() {
+		entriesInFile, e := entriesFrom(path, *format, csvColumns)
+		if e != nil {
+			cli.ExitWithError(e)
+		}
+		entries = append(entries, entriesInFile...)
+	}
+
+	ctx := context.Background()
+	journal, e := journalFlags.Journal(ctx, false)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	result, e := journal.ImportEntries(ctx, entries, *batchSize)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	fmt.Printf("Imported %v entries. Skipped %v entries that existed already.\n", result.Imported, result.Duplicates)
+}
+
+func entriesFrom(path string, format string, csvColumns importer.CSVColumns) ([]j.Entry, error) {
+	if format == "tsv" {
+		return importer.TSV(path)
+	}
+	file, e := os.Open(path)
+	if e != nil {
+		return nil, e
+	}
+	defer file.Close()
+
+	switch format {
+	case "dayone":
+		return importer.DayOne(file)
+	case "journey":
+		entry, e := importer.Journey(file)
+		if e != nil {
+			return nil, fmt.Errorf("%v: %v", path, e)
+		}
+		return []j.Entry{entry}, nil
+	case "csv":
+		return importer.CSV(file, csvColumns)
+	default:
+		return nil, fmt.Errorf("Unknown format %v", format)
+	}
+}
@@ -0,0 +1,80 @@
+// Package cli contains what the command line tools have in common.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/petergtz/alexa-journal/drive"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+	"go.uber.org/zap"
+)
+
+// JournalFlags selects the journal a command works on: either a local TSV file or the journal's Google Sheet.
+type JournalFlags struct {
+	TSVPath   string
+	SheetName string
+	// Create creates the journal if it doesn't exist. Otherwise, a journal that doesn't exist is an error, so that a
+	// typo doesn't create an empty journal.
+	Create bool
+}
+
+func (f *JournalFlags) Register(flags *flag.FlagSet) {
+	flags.StringVar(&f.TSVPath, "tsv", "", "Path of a journal in TSV format")
+	flags.StringVar(&f.SheetName, "sheet", "", "Name of the journal's Google Sheet. Requires GOOGLE_DRIVE_TOKEN to be set")
+}
+
+// Journal opens the selected journal. A read-only journal never writes to a TSV file.
+func (f *JournalFlags) Journal(ctx context.Context, readOnly bool) (*j.Journal, error) {
+	switch {
+	case f.TSVPath != "" && f.SheetName != "":
+		return nil, fmt.Errorf("Please specify either -tsv or -sheet, not both")
+	case f.TSVPath != "":
+		if f.Create {
+			if e := createIfNotExists(f.TSVPath); e != nil {
+				return nil, e
+			}
+		}
+		return &j.Journal{Data: &tsv.TextFileBackedTabularData{TextFileLoader: &tsv.LocalFile{Path: f.TSVPath, ReadOnly: readOnly}}}, nil
+	case f.SheetName != "":
+		token := os.Getenv("GOOGLE_DRIVE_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("Please provide GOOGLE_DRIVE_TOKEN")
+		}
+		logger, e := zap.NewDevelopment()
+		if e != nil {
+			return nil, e
+		}
+		var data *drive.SheetBasedTabularData
+		if f.Create {
+			data, e = drive.NewSheetBasedTabularData(ctx, token, f.SheetName, f.SheetName, logger.Sugar())
+		} else {
+			data, e = drive.FindSheetBasedTabularData(ctx, token, f.SheetName, f.SheetName, logger.Sugar())
+		}
+		if drive.IsFileNotFoundError(e) {
+			return nil, fmt.Errorf("There is no Google Sheet named %v", f.SheetName)
+		}
+		if e != nil {
+			return nil, e
+		}
+		return &j.Journal{Data: data}, nil
+	default:
+		return nil, fmt.Errorf("Please specify -tsv or -sheet")
+	}
+}
+
+func createIfNotExists(path string) error {
+	file, e := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0644)
+	if e != nil {
+		return e
+	}
+	return file.Close()
+}
+
+func ExitWithError(e error) {
+	fmt.Fprintln(os.Stderr, "Error:", e)
+	os.Exit(1)
+}
@@ -0,0 +1,107 @@
+// Command journal-admin inspects and repairs a user's journal.
+//
+//	journal-admin validate -tsv journal.tsv
+//	journal-admin repair -tsv journal.tsv -sort -dry-run
+//	GOOGLE_DRIVE_TOKEN=... journal-admin repair -sheet Journal -sort
+//
+// validate reports every anomaly, e.g. invalid dates or duplicate IDs. repair fixes what can be fixed
+// automatically, normalises dates to ISO format, optionally re-sorts entries by date and reports what
+// needs to be fixed by hand. Row numbers are the ones shown in Google Sheets, i.e. they start at 1.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/petergtz/alexa-journal/cmd/internal/cli"
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		cli.ExitWithError(fmt.Errorf("Please specify a command: validate or repair"))
+	}
+	switch os.Args[1] {
+	case "validate":
+		validate(os.Args[2:])
+	case "repair":
+		repair(os.Args[2:])
+	default:
+		cli.ExitWithError(fmt.Errorf("Unknown command %v. Please specify validate or repair", os.Args[1]))
+	}
+}
+
+func validate(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var journalFlags cli.JournalFlags
+	journalFlags.Register(flags)
+	flags.Parse(args)
+
+	ctx := context.Background()
+	journal, e := journalFlags.Journal(ctx, true)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	anomalies, e := j.Inspect(ctx, journal.Data)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	if len(anomalies) == 0 {
+		fmt.Println("No anomalies found.")
+		return
+	}
+	for _, anomaly := range anomalies {
+		fixable := ""
+		if anomaly.Fixable() || anomaly.Kind == j.OutOfOrder {
+			fixable = " (repairable)"
+		}
+		fmt.Printf("%v%v\n", anomaly, fixable)
+	}
+	os.Exit(2)
+}
+
+func repair(args []string) {
+	flags := flag.NewFlagSet("repair", flag.ExitOnError)
+	var journalFlags cli.JournalFlags
+	journalFlags.Register(flags)
+	var options j.RepairOptions
+	flags.BoolVar(&options.Sort, "sort", false, "Re-sort entries by date")
+	flags.BoolVar(&options.DryRun, "dry-run", false, "Only show what would be repaired")
+	flags.Parse(args)
+
+	ctx := context.Background()
+	journal, e := journalFlags.Journal(ctx, options.DryRun)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+	report, e := j.Repair(ctx, journal.Data, options)
+	if e != nil {
+		cli.ExitWithError(e)
+	}
+
+	verb := "Fixed"
+	if options.DryRun {
+		verb = "Would fix"
+	}
+	for _, anomaly := range report.Fixed {
+		fmt.Printf("%v %v\n", verb, anomaly)
+	}
+	var rowNums []int
+	for rowNum := range report.Updates {
+		rowNums = append(rowNums, rowNum)
+	}
+	sort.Ints(rowNums)
+	for _, rowNum := range rowNums {
+		fmt.Printf("row %v := %v\n", rowNum+1, strings.Join(report.Updates[rowNum], " | "))
+	}
+	for _, anomaly := range report.Unfixable {
+		fmt.Printf("Please fix by hand: %v\n", anomaly)
+	}
+	if len(report.Unfixable) > 0 {
+		os.Exit(2)
+	}
+}
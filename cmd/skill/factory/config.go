@@ -0,0 +1,187 @@
+package factory
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+)
+
+// DefaultConfigFile is read when CONFIG_FILE is not set. It's fine if it doesn't exist, as long as environment
+// variables provide the required settings.
+const DefaultConfigFile = "config.toml"
+
+// Config describes a deployment of the skill: which integrations it uses and where they are. It's read from a TOML
+// file, and environment variables override single settings, so that secrets like the Github token don't have to be
+// in the file. Integrations are optional, except for the DynamoDB table as Lambda function, where user settings
+// kept in memory would get lost with every function instance. Without them, errors are only logged and, in HTTP mode,
+// user settings are kept in memory.
+type Config struct {
+	// HTTPAddr makes the skill serve requests over HTTP instead of running as Lambda function, e.g. ":8080".
+	HTTPAddr string `toml:"http_addr"`
+	// AlexaApplicationID is the skill ID requests are checked against in HTTP mode.
+	AlexaApplicationID string `toml:"alexa_application_id"`
+	// AuditTrail logs a JSON line for every entry that gets added, deleted or edited.
+	AuditTrail bool `toml:"audit_trail"`
+	// DebugUnredactedLogs keeps personal data in logs and error reports. Only meant for debugging.
+	DebugUnredactedLogs bool `toml:"debug_unredacted_logs"`
+
+	UserConfig   UserConfigConfig   `toml:"user_config"`
+	ErrorReports ErrorReportsConfig `toml:"error_reports"`
+	Tracing      TracingConfig      `toml:"tracing"`
+	Metrics      MetricsConfig      `toml:"metrics"`
+}
+
+// UserConfigConfig tells where user settings are stored. Without a table they are kept in memory, which is only
+// allowed in HTTP mode.
+type UserConfigConfig struct {
+	DynamoDBTable  string `toml:"dynamodb_table"`
+	DynamoDBRegion string `toml:"dynamodb_region"`
+}
+
+type ErrorReportsConfig struct {
+	// LogsQuery is a format string turning an error ID into a query or link that finds the error in the logs.
+	LogsQuery string `toml:"logs_query"`
+	// TraceURL is a format string turning a trace ID into a link to the trace.
+	TraceURL string `toml:"trace_url"`
+
+	GithubToken   string `toml:"github_token"`
+	GithubOwner   string `toml:"github_owner"`
+	GithubRepo    string `toml:"github_repo"`
+	GithubBaseURL string `toml:"github_base_url"`
+
+	SNSTopicArn string `toml:"sns_topic_arn"`
+	SNSRegion   string `toml:"sns_region"`
+	SNSSubject  string `toml:"sns_subject"`
+
+	File       string `toml:"file"`
+	WebhookURL string `toml:"webhook_url"`
+
+	SuppressRepeatsFor duration `toml:"suppress_repeats_for"`
+	MaxReportsPerHour  int      `toml:"max_reports_per_hour"`
+}
+
+type TracingConfig struct {
+	// Exporter is stdout, otlp or empty, which disables tracing.
+	Exporter    string `toml:"exporter"`
+	ServiceName string `toml:"service_name"`
+}
+
+type MetricsConfig struct {
+	// Namespace is the CloudWatch namespace in Lambda and the metric name prefix for Prometheus in HTTP mode.
+	Namespace string `toml:"namespace"`
+}
+
+// duration can be read from TOML strings like "10m".
+type duration struct {
+	time.Duration
+}
+
+func (d *duration) UnmarshalText(text []byte) (e error) {
+	d.Duration, e = time.ParseDuration(string(text))
+	return
+}
+
+// DefaultConfig is the configuration of a deployment that has no integrations set up.
+func DefaultConfig() Config {
+	return Config{
+		ErrorReports: ErrorReportsConfig{
+			LogsQuery:          "``fields @timestamp, @message | filter `error-id` = %v``",
+			SNSSubject:         "alexa-journal",
+			SuppressRepeatsFor: duration{10 * time.Minute},
+			MaxReportsPerHour:  20,
+		},
+		Tracing: TracingConfig{ServiceName: "alexa-journal"},
+		Metrics: MetricsConfig{Namespace: "AlexaJournal"},
+	}
+}
+
+// LoadConfig reads the config from the TOML file at path and applies the environment variables found by lookupEnv
+// on top. If path is empty, DefaultConfigFile is read if it exists. Incomplete settings are an error.
+func LoadConfig(path string, lookupEnv func(key string) (string, bool)) (Config, error) {
+	config := DefaultConfig()
+	if path == "" {
+		if _, e := os.Stat(DefaultConfigFile); e == nil {
+			path = DefaultConfigFile
+		}
+	}
+	if path != "" {
+		if _, e := toml.DecodeFile(path, &config); e != nil {
+			return Config{}, errors.Wrapf(e, "Could not read config file %v", path)
+		}
+	}
+	if e := config.applyEnv(lookupEnv); e != nil {
+		return Config{}, e
+	}
+	if e := config.validate(); e != nil {
+		return Config{}, e
+	}
+	return config, nil
+}
+
+func (config *Config) applyEnv(lookupEnv func(key string) (string, bool)) error {
+	stringSettings := map[string]*string{
+		"HTTP_ADDR":                &config.HTTPAddr,
+		"ALEXA_APPLICATION_ID":     &config.AlexaApplicationID,
+		"CONFIG_DYNAMODB_TABLE":    &config.UserConfig.DynamoDBTable,
+		"CONFIG_DYNAMODB_REGION":   &config.UserConfig.DynamoDBRegion,
+		"LOGS_QUERY":               &config.ErrorReports.LogsQuery,
+		"TRACE_URL":                &config.ErrorReports.TraceURL,
+		"GITHUB_TOKEN":             &config.ErrorReports.GithubToken,
+		"GITHUB_OWNER":             &config.ErrorReports.GithubOwner,
+		"GITHUB_REPO":              &config.ErrorReports.GithubRepo,
+		"GITHUB_BASE_URL":          &config.ErrorReports.GithubBaseURL,
+		"ERROR_SNS_TOPIC_ARN":      &config.ErrorReports.SNSTopicArn,
+		"ERROR_SNS_REGION":         &config.ErrorReports.SNSRegion,
+		"ERROR_REPORT_FILE":        &config.ErrorReports.File,
+		"ERROR_REPORT_WEBHOOK_URL": &config.ErrorReports.WebhookURL,
+		"OTEL_TRACES_EXPORTER":     &config.Tracing.Exporter,
+		"OTEL_SERVICE_NAME":        &config.Tracing.ServiceName,
+		"METRICS_NAMESPACE":        &config.Metrics.Namespace,
+	}
+	for key, field := range stringSettings {
+		if value, isSet := lookupEnv(key); isSet {
+			*field = value
+		}
+	}
+	boolSettings := map[string]*bool{
+		"AUDIT_TRAIL":           &config.AuditTrail,
+		"DEBUG_UNREDACTED_LOGS": &config.DebugUnredactedLogs,
+	}
+	for key, field := range boolSettings {
+		if value, isSet := lookupEnv(key); isSet {
+			b, e := strconv.ParseBool(value)
+			if e != nil {
+				return errors.Wrapf(e, "Invalid value for %v", key)
+			}
+			*field = b
+		}
+	}
+	if config.Tracing.Exporter == "none" {
+		config.Tracing.Exporter = ""
+	}
+	return nil
+}
+
+// validate reports settings that are incomplete, e.g. a Github token without repository, or missing for the mode the
+// skill runs in.
+func (config *Config) validate() error {
+	if config.ErrorReports.GithubToken != "" && (config.ErrorReports.GithubOwner == "" || config.ErrorReports.GithubRepo == "") {
+		return errors.New("Github token given, but Github owner or repo missing")
+	}
+	if config.ErrorReports.SNSTopicArn != "" && config.ErrorReports.SNSRegion == "" {
+		return errors.New("SNS topic given, but SNS region missing")
+	}
+	if config.UserConfig.DynamoDBTable != "" && config.UserConfig.DynamoDBRegion == "" {
+		return errors.New("DynamoDB table given, but DynamoDB region missing")
+	}
+	if config.HTTPAddr != "" && config.AlexaApplicationID == "" {
+		return errors.New("HTTP mode requires the Alexa application ID")
+	}
+	if config.HTTPAddr == "" && config.UserConfig.DynamoDBTable == "" {
+		return errors.New("Lambda mode requires a DynamoDB table for user settings")
+	}
+	return nil
+}
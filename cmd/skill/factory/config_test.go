@@ -0,0 +1,185 @@
+package factory_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	skill "github.com/petergtz/alexa-journal"
+	"github.com/petergtz/alexa-journal/cmd/skill/factory"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		dir        string
+		workingDir string
+		env        map[string]string
+	)
+
+	lookupEnv := func(key string) (string, bool) {
+		value, isSet := env[key]
+		return value, isSet
+	}
+
+	writeConfigFile := func(content string) string {
+		path := filepath.Join(dir, "config.toml")
+		Expect(ioutil.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		var e error
+		dir, e = ioutil.TempDir("", "factory")
+		Expect(e).NotTo(HaveOccurred())
+		workingDir, e = os.Getwd()
+		Expect(e).NotTo(HaveOccurred())
+		env = map[string]string{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("fails when the given file doesn't exist", func() {
+		_, e := factory.LoadConfig(filepath.Join(dir, "non-existent.toml"), lookupEnv)
+
+		Expect(e).To(MatchError(ContainSubstring("Could not read config file")))
+	})
+
+	It("has no integrations in HTTP mode without settings", func() {
+		env["HTTP_ADDR"] = ":8080"
+		env["ALEXA_APPLICATION_ID"] = "amzn1.ask.skill.test"
+
+		config, e := factory.LoadConfig(writeConfigFile(""), lookupEnv)
+		Expect(e).NotTo(HaveOccurred())
+		expected := factory.DefaultConfig()
+		expected.HTTPAddr = ":8080"
+		expected.AlexaApplicationID = "amzn1.ask.skill.test"
+		Expect(config).To(Equal(expected))
+		Expect(config.UserConfig.DynamoDBTable).To(BeEmpty())
+		Expect(config.ErrorReports.GithubToken).To(BeEmpty())
+		Expect(config.ErrorReports.SNSTopicArn).To(BeEmpty())
+		Expect(config.Tracing.Exporter).To(BeEmpty())
+		Expect(config.ErrorReports.SuppressRepeatsFor.Duration).To(Equal(10 * time.Minute))
+		Expect(config.ErrorReports.MaxReportsPerHour).To(Equal(20))
+	})
+
+	It("reads the TOML file", func() {
+		config, e := factory.LoadConfig(writeConfigFile(`
+audit_trail = true
+
+[user_config]
+dynamodb_table = "Config"
+dynamodb_region = "eu-central-1"
+
+[error_reports]
+github_owner = "owner"
+github_repo = "repo"
+github_token = "token"
+suppress_repeats_for = "1h"
+max_reports_per_hour = 5
+
+[tracing]
+exporter = "otlp"
+`), lookupEnv)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(config.AuditTrail).To(BeTrue())
+		Expect(config.UserConfig).To(Equal(factory.UserConfigConfig{DynamoDBTable: "Config", DynamoDBRegion: "eu-central-1"}))
+		Expect(config.ErrorReports.GithubOwner).To(Equal("owner"))
+		Expect(config.ErrorReports.GithubRepo).To(Equal("repo"))
+		Expect(config.ErrorReports.GithubToken).To(Equal("token"))
+		Expect(config.ErrorReports.SuppressRepeatsFor.Duration).To(Equal(time.Hour))
+		Expect(config.ErrorReports.MaxReportsPerHour).To(Equal(5))
+		Expect(config.Tracing.Exporter).To(Equal("otlp"))
+		Expect(config.Tracing.ServiceName).To(Equal("alexa-journal"))
+	})
+
+	It("lets environment variables override the file", func() {
+		env["GITHUB_TOKEN"] = "secret"
+		env["AUDIT_TRAIL"] = "false"
+		env["OTEL_TRACES_EXPORTER"] = "none"
+		env["ERROR_REPORT_FILE"] = "/tmp/errors.jsonl"
+
+		config, e := factory.LoadConfig(writeConfigFile(`
+audit_trail = true
+
+[user_config]
+dynamodb_table = "Config"
+dynamodb_region = "eu-central-1"
+
+[error_reports]
+github_owner = "owner"
+github_repo = "repo"
+
+[tracing]
+exporter = "stdout"
+`), lookupEnv)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(config.ErrorReports.GithubToken).To(Equal("secret"))
+		Expect(config.AuditTrail).To(BeFalse())
+		Expect(config.Tracing.Exporter).To(BeEmpty())
+		Expect(config.ErrorReports.File).To(Equal("/tmp/errors.jsonl"))
+	})
+
+	It("rejects invalid boolean environment variables", func() {
+		env["DEBUG_UNREDACTED_LOGS"] = "yes please"
+
+		_, e := factory.LoadConfig(writeConfigFile(""), lookupEnv)
+
+		Expect(e).To(MatchError(ContainSubstring("Invalid value for DEBUG_UNREDACTED_LOGS")))
+	})
+
+	It("fails as Lambda function without DynamoDB table, e.g. when config.toml is missing", func() {
+		Expect(os.Chdir(dir)).To(Succeed())
+		defer os.Chdir(workingDir)
+
+		_, e := factory.LoadConfig("", lookupEnv)
+
+		Expect(e).To(MatchError(ContainSubstring("Lambda mode requires a DynamoDB table")))
+	})
+
+	It("rejects incomplete integrations", func() {
+		env["GITHUB_TOKEN"] = "secret"
+		_, e := factory.LoadConfig(writeConfigFile(""), lookupEnv)
+		Expect(e).To(MatchError(ContainSubstring("Github owner or repo missing")))
+
+		env = map[string]string{"ERROR_SNS_TOPIC_ARN": "arn"}
+		_, e = factory.LoadConfig(writeConfigFile(""), lookupEnv)
+		Expect(e).To(MatchError(ContainSubstring("SNS region missing")))
+
+		env = map[string]string{"CONFIG_DYNAMODB_TABLE": "Config"}
+		_, e = factory.LoadConfig(writeConfigFile(""), lookupEnv)
+		Expect(e).To(MatchError(ContainSubstring("DynamoDB region missing")))
+
+		env = map[string]string{"HTTP_ADDR": ":8080", "CONFIG_DYNAMODB_TABLE": "Config", "CONFIG_DYNAMODB_REGION": "eu-central-1"}
+		_, e = factory.LoadConfig(writeConfigFile(""), lookupEnv)
+		Expect(e).To(MatchError(ContainSubstring("Alexa application ID")))
+	})
+
+	It("is valid for the production deployment", func() {
+		config, e := factory.LoadConfig("../config.toml", lookupEnv)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(config.UserConfig.DynamoDBTable).To(Equal("AlexaJournalConfig"))
+		Expect(config.ErrorReports.SNSTopicArn).NotTo(BeEmpty())
+	})
+})
+
+var _ = Describe("MemoryConfigService", func() {
+	It("keeps configs per user and defaults like DynamoDB", func() {
+		configService := factory.NewMemoryConfigService()
+
+		Expect(configService.GetConfig(context.Background(), "user1")).To(Equal(skill.Config{ShouldExplainAboutSuccinctMode: true}))
+
+		configService.PersistConfig(context.Background(), "user1", skill.Config{BeSuccinct: true})
+
+		Expect(configService.GetConfig(context.Background(), "user1")).To(Equal(skill.Config{BeSuccinct: true}))
+		Expect(configService.GetConfig(context.Background(), "user2")).To(Equal(skill.Config{ShouldExplainAboutSuccinctMode: true}))
+	})
+})
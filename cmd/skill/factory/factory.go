@@ -1,53 +1,152 @@
 package factory
 
 import (
+	"context"
+	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/text/language"
 
 	skill "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/dynamodb"
+	"github.com/petergtz/alexa-journal/errorreport"
 	"github.com/petergtz/alexa-journal/github"
 	"github.com/petergtz/alexa-journal/locale/resources"
+	"github.com/petergtz/alexa-journal/metrics"
+	"github.com/petergtz/alexa-journal/progressive"
+	"github.com/petergtz/alexa-journal/redact"
+	"github.com/petergtz/alexa-journal/reminders"
+	"github.com/petergtz/alexa-journal/tracing"
 
 	"github.com/petergtz/alexa-journal/drive"
 
 	"go.uber.org/zap"
 )
 
-func CreateSkill(logger *zap.SugaredLogger) *skill.JournalSkill {
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	if githubToken == "" {
-		logger.Fatal("GITHUB_TOKEN not set. Please set it to a valid token from Github.")
+// CreateSkill wires up the skill with the integrations configured in config.
+func CreateSkill(config Config, logger *zap.SugaredLogger, redactor redact.Redactor, m metrics.Metrics) *skill.JournalSkill {
+	errorReporter := CreateErrorReporter(config.ErrorReports, logger, redactor)
+
+	latency := &skill.LatencyMetrics{Metrics: m}
+	responseInterceptors := []skill.ResponseInterceptor{latency}
+	if config.AuditTrail {
+		responseInterceptors = append(responseInterceptors, &skill.AuditTrail{Writer: os.Stdout})
 	}
-	githubErrorReporter := github.NewGithubErrorReporter(
-		"petergtz",
-		"alexa-journal",
-		githubToken,
-		logger,
-		"``fields @timestamp, @message | filter `error-id` = %v``",
-		sns.New(session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-west-1")}))),
-		"arn:aws:sns:eu-west-1:512841817041:AlexaJournalErrors")
 
 	return skill.NewJournalSkill(
-		drive.NewDriveSheetJournalProvider(logger),
-		&drive.DriveSheetErrorInterpreter{ErrorReporter: githubErrorReporter},
+		drive.NewDriveSheetJournalProvider(logger, m),
+		&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
 		logger,
-		githubErrorReporter,
+		errorReporter,
 		CreateI18nBundle(),
-		dynamodb.CreateConfigService("AlexaJournalConfig", "eu-central-1", githubErrorReporter),
-	)
+		CreateConfigService(config.UserConfig, logger, errorReporter, m),
+		reminders.NewClient(&http.Client{Timeout: 5 * time.Second}),
+		&drive.DriveFileWriter{Log: logger},
+		progressive.NewClient(&http.Client{Timeout: 2 * time.Second}),
+	).
+		UseRequestInterceptors(latency, skill.NewRequestLogger(redactor)).
+		UseResponseInterceptors(responseInterceptors...)
+}
+
+// CreateErrorReporter returns a reporter that sends errors to all configured sinks. Without any, errors are only
+// logged.
+func CreateErrorReporter(config ErrorReportsConfig, logger *zap.SugaredLogger, redactor redact.Redactor) *errorreport.Reporter {
+	var sinks []errorreport.Sink
+	if config.GithubToken != "" {
+		issueSink := github.NewIssueSink(config.GithubOwner, config.GithubRepo, config.GithubToken, config.LogsQuery, config.TraceURL)
+		if config.GithubBaseURL != "" {
+			issueSink.WithBaseURL(config.GithubBaseURL)
+		}
+		sinks = append(sinks, issueSink)
+	}
+	if config.SNSTopicArn != "" {
+		sinks = append(sinks, &errorreport.SNSSink{
+			Client:   sns.New(session.Must(session.NewSession(&aws.Config{Region: aws.String(config.SNSRegion)}))),
+			TopicArn: config.SNSTopicArn,
+			Subject:  config.SNSSubject,
+			LogsURL:  config.LogsQuery,
+		})
+	}
+	if config.File != "" {
+		sinks = append(sinks, &errorreport.FileSink{Path: config.File})
+	}
+	if config.WebhookURL != "" {
+		sinks = append(sinks, &errorreport.WebhookSink{URL: config.WebhookURL, HTTPClient: &http.Client{Timeout: 2 * time.Second}})
+	}
+	if len(sinks) == 0 {
+		logger.Warn("No error report sinks configured. Errors will only be logged.")
+	}
+	return errorreport.NewReporter(logger, redactor, sinks...).
+		WithRateLimit(config.SuppressRepeatsFor.Duration, config.MaxReportsPerHour)
+}
+
+// CreateConfigService returns a ConfigService storing user settings in the configured DynamoDB table, or in memory,
+// if there is none. LoadConfig makes sure there is one as Lambda function.
+func CreateConfigService(config UserConfigConfig, logger *zap.SugaredLogger, errorReporter skill.ErrorReporter, m metrics.Metrics) skill.ConfigService {
+	if config.DynamoDBTable == "" {
+		logger.Warn("No DynamoDB table configured. User settings will be kept in memory and get lost.")
+		return NewMemoryConfigService()
+	}
+	return dynamodb.CreateConfigService(config.DynamoDBTable, config.DynamoDBRegion, errorReporter, m)
+}
+
+// CreateTracerProvider returns a TracerProvider exporting spans as configured, or nil if tracing is disabled, so
+// that no spans are recorded.
+func CreateTracerProvider(config TracingConfig, logger *zap.SugaredLogger) *sdktrace.TracerProvider {
+	if config.Exporter == "" {
+		return nil
+	}
+	tracerProvider, e := tracing.NewTracerProvider(context.Background(), config.Exporter, config.ServiceName, os.Stdout)
+	if e != nil {
+		logger.Fatalw("Could not create tracer provider", "error", e)
+	}
+	return tracerProvider
 }
 
-type EmptyConfigService struct{}
+// CreateRedactor returns the Redactor for logs and error reports. Personal data is redacted unless
+// DebugUnredactedLogs is set.
+func CreateRedactor(config Config, logger *zap.SugaredLogger) redact.Redactor {
+	if config.DebugUnredactedLogs {
+		logger.Warn("DEBUG_UNREDACTED_LOGS is set. Logs and error reports will contain personal data.")
+		return redact.Redactor{Disabled: true}
+	}
+	return redact.Redactor{}
+}
+
+// MemoryConfigService keeps user settings in memory. They get lost when the process ends.
+type MemoryConfigService struct {
+	mutex   sync.Mutex
+	configs map[string]skill.Config
+}
 
-func (*EmptyConfigService) GetConfig(userID string) skill.Config             { return skill.Config{} }
-func (*EmptyConfigService) PersistConfig(userID string, config skill.Config) {}
+func NewMemoryConfigService() *MemoryConfigService {
+	return &MemoryConfigService{configs: make(map[string]skill.Config)}
+}
+
+func (cs *MemoryConfigService) GetConfig(ctx context.Context, userID string) skill.Config {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	config, exists := cs.configs[userID]
+	if !exists {
+		// Same defaults as for new users in DynamoDB
+		return skill.Config{ShouldExplainAboutSuccinctMode: true}
+	}
+	return config
+}
+
+func (cs *MemoryConfigService) PersistConfig(ctx context.Context, userID string, config skill.Config) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.configs[userID] = config
+}
 
 func CreateI18nBundle() *i18n.Bundle {
 	i18nBundle := i18n.NewBundle(language.English)
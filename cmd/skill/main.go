@@ -1,13 +1,26 @@
 package main
 
 import (
+	"context"
 	"log"
 	"math/rand"
+	"net/http"
+	"os"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	journalskill "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/cmd/skill/factory"
+	"github.com/petergtz/alexa-journal/metrics"
+	"github.com/petergtz/alexa-journal/redact"
 
-	"github.com/petergtz/go-alexa/lambda"
+	"github.com/petergtz/go-alexa"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"go.uber.org/zap"
 )
@@ -18,7 +31,88 @@ func main() {
 	logger := createLoggerWith(zap.NewAtomicLevelAt(zap.DebugLevel))
 	defer logger.Sync()
 
-	lambda.StartLambdaSkill(factory.CreateSkill(logger), logger)
+	config, e := factory.LoadConfig(os.Getenv("CONFIG_FILE"), os.LookupEnv)
+	if e != nil {
+		logger.Fatalw("Could not load config", "error", e)
+	}
+
+	redactor := factory.CreateRedactor(config, logger)
+	tracerProvider := factory.CreateTracerProvider(config.Tracing, logger)
+	if tracerProvider != nil {
+		otel.SetTracerProvider(tracerProvider)
+		defer tracerProvider.Shutdown(context.Background())
+	}
+	if config.HTTPAddr != "" {
+		m := metrics.NewPrometheus(snakeCase(config.Metrics.Namespace))
+		startHTTPSkill(config.HTTPAddr, config.AlexaApplicationID, factory.CreateSkill(config, logger, redactor, m), m, logger)
+		return
+	}
+	m := &metrics.EMF{Writer: os.Stdout, Namespace: config.Metrics.Namespace}
+	startLambdaSkill(factory.CreateSkill(config, logger, redactor, m), logger, redactor, tracerProvider)
+}
+
+// startHTTPSkill serves the skill as an HTTPS endpoint for Alexa behind a TLS-terminating proxy, and its metrics
+// at /metrics. Alexa APIs such as the Reminders API are not available in this mode, because go-alexa's handler
+// doesn't pass on the request context.
+func startHTTPSkill(addr string, applicationID string, skill *journalskill.JournalSkill, m *metrics.Prometheus, logger *zap.SugaredLogger) {
+	handler := &alexa.Handler{
+		Skill:                 skill,
+		Log:                   logger,
+		ExpectedApplicationID: applicationID,
+	}
+	http.HandleFunc("/", handler.Handle)
+	http.Handle("/metrics", m.Handler())
+	logger.Infow("Serving skill", "addr", addr)
+	logger.Fatal(http.ListenAndServe(addr, nil))
+}
+
+// startLambdaSkill works like go-alexa's lambda.StartLambdaSkill, but also decodes the request context,
+// which the skill needs to call Alexa APIs.
+func startLambdaSkill(skill *journalskill.JournalSkill, logger *zap.SugaredLogger, redactor redact.Redactor, tracerProvider *sdktrace.TracerProvider) {
+	invocationCount := 0
+	lambda.Start(func(ctx context.Context, requestEnv journalskill.RequestEnvelope) (alexa.ResponseEnvelope, error) {
+		invocationCount++
+		lc, _ := lambdacontext.FromContext(ctx)
+
+		if requestEnv.Request == nil {
+			logger.Infow("Keep-alive CloudWatch Request",
+				"aws-request-id", lc.AwsRequestID,
+				"function-invocation-count", invocationCount)
+
+			return alexa.ResponseEnvelope{}, nil
+		}
+		logger.Infow("Alexa Request",
+			"aws-request-id", lc.AwsRequestID,
+			"alexa-request-id", requestEnv.Request.RequestID,
+			"function-invocation-count", invocationCount,
+			"type", requestEnv.Request.Type,
+			"intent", redactor.Request(requestEnv.Request).Intent,
+			"session-attributes", redactor.SessionAttributes(requestEnv.Session.Attributes),
+			"locale", requestEnv.Request.Locale,
+			"user-id", redactor.UserID(requestEnv.Session.User.UserID),
+			"session-id", requestEnv.Session.SessionID)
+
+		responseEnv := skill.ProcessRequestWithContext(&requestEnv.RequestEnvelope, requestEnv.Context)
+		if tracerProvider != nil {
+			// The function gets frozen after returning, so spans must be exported before.
+			if e := tracerProvider.ForceFlush(ctx); e != nil {
+				logger.Errorw("Could not export spans", "error", e)
+			}
+		}
+		return *responseEnv, nil
+	})
+}
+
+// snakeCase turns CloudWatch style namespaces like "AlexaJournal" into Prometheus style ones like "alexa_journal".
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
 }
 
 func createLoggerWith(logLevel zap.AtomicLevel) *zap.SugaredLogger {
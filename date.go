@@ -46,3 +46,19 @@ func DateFrom(dateString string) (dayDate date.Date, monthDate string, dateType
 	}
 	return entryDate, "", DayDate
 }
+
+// TimeRangeFrom converts a date slot value into a prefix of ISO dates, e.g. "2019" for a year or
+// "2019-03" for a month, which is what the journal uses to select entries in a time range.
+func TimeRangeFrom(dateString string) (timeRange string, ok bool) {
+	dayDate, monthDate, dateType := DateFrom(dateString)
+	switch dateType {
+	case DayDate:
+		return dayDate.String(), true
+	case MonthDate:
+		return monthDate, true
+	case YearDate:
+		return dateString[:4], true
+	default:
+		return "", false
+	}
+}
@@ -55,4 +55,19 @@ var _ = Describe("Date", func() {
 		Expect(monthDate).To(BeEmpty())
 	})
 
+	It("converts date slot values to time ranges", func() {
+		for dateString, expected := range map[string]string{
+			"2019":       "2019",
+			"2019-XX-XX": "2019",
+			"2019-03":    "2019-03",
+			"2019-03-05": "2019-03-05",
+		} {
+			timeRange, ok := TimeRangeFrom(dateString)
+			Expect(ok).To(BeTrue())
+			Expect(timeRange).To(Equal(expected))
+		}
+
+		_, ok := TimeRangeFrom("2019-W12")
+		Expect(ok).To(BeFalse())
+	})
 })
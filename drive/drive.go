@@ -1,10 +1,12 @@
 package drive
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
 	"strings"
-	"time"
 
+	j "github.com/petergtz/alexa-journal/journal"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/api/drive/v3"
@@ -16,40 +18,58 @@ type FileService struct {
 	log    *zap.SugaredLogger
 }
 
-func NewFileService(accessToken string, filename string, log *zap.SugaredLogger) (*FileService, error) {
-	startTime := time.Now()
-	driveService := newDriveService(accessToken)
-	log.Debugw("Time taken to create drive client", "time", time.Since(startTime))
+func NewFileService(ctx context.Context, accessToken string, filename string, log *zap.SugaredLogger) (*FileService, error) {
+	return newFileService(ctx, accessToken, "", filename, strings.Join(j.Header, "\t")+"\n", log)
+}
+
+// NewExportFileService returns a FileService for an export of a journal. Unlike journal files, the file is created
+// empty. It's looked for and created in the folder with folderID, or anywhere in the user's Drive if that's empty.
+func NewExportFileService(ctx context.Context, accessToken string, folderID string, filename string, log *zap.SugaredLogger) (*FileService, error) {
+	return newFileService(ctx, accessToken, folderID, filename, "", log)
+}
 
-	startTime = time.Now()
-	fileID, e := fileIDFrom(driveService.Files, filename, log)
+func newFileService(ctx context.Context, accessToken string, folderID string, filename string, initialContent string, log *zap.SugaredLogger) (*FileService, error) {
+	driveService := newDriveService(accessToken)
+	fileID, e := fileIDFrom(ctx, driveService.Files, filename, folderID, log)
 	if e != nil {
 		return nil, e
 	}
 	if fileID == "" {
 		log.Infof("File %v does not exist. Creating it.", filename)
-		file, e := driveService.Files.Create(&drive.File{Name: filename}).Media(strings.NewReader("timestamp\tdate\ttext\n")).Do()
+		file := &drive.File{Name: filename}
+		if folderID != "" {
+			file.Parents = []string{folderID}
+		}
+		e := withRetries(ctx, log, "drive.files.create", isRateLimited, func(ctx context.Context) (e error) {
+			file, e = driveService.Files.Create(file).Media(strings.NewReader(initialContent)).Context(ctx).Do()
+			return
+		})
 		if e != nil {
 			return nil, NewCannotCreateFileError(filename, e)
 		}
 		fileID = file.Id
 	}
 
-	log.Debugw("Time taken to get or create file in drive", "time", time.Since(startTime))
-
-	return &FileService{FileID: fileID, files: driveService.Files}, nil
+	return &FileService{FileID: fileID, files: driveService.Files, log: log}, nil
 }
 
-func (dfs *FileService) Upload(content string) error {
-	_, e := dfs.files.Update(dfs.FileID, &drive.File{}).Media(strings.NewReader(content)).Do()
+func (dfs *FileService) Upload(ctx context.Context, content string) error {
+	e := withRetries(ctx, dfs.log, "drive.files.update", isTransient, func(ctx context.Context) error {
+		_, e := dfs.files.Update(dfs.FileID, &drive.File{}).Media(strings.NewReader(content)).Context(ctx).Do()
+		return e
+	})
 	if e != nil {
 		return errors.Wrap(e, "Could not upload file contents")
 	}
 	return nil
 }
 
-func (dfs *FileService) Download() (string, error) {
-	download, e := dfs.files.Get(dfs.FileID).Download()
+func (dfs *FileService) Download(ctx context.Context) (string, error) {
+	var download *http.Response
+	e := withRetries(ctx, dfs.log, "drive.files.get", isTransient, func(ctx context.Context) (e error) {
+		download, e = dfs.files.Get(dfs.FileID).Context(ctx).Download()
+		return
+	})
 	if e != nil {
 		return "", errors.Wrap(e, "Could not download file")
 	}
@@ -62,3 +82,16 @@ func (dfs *FileService) Download() (string, error) {
 
 	return string(byteContent), nil
 }
+
+// DriveFileWriter writes whole files to the user's Google Drive, e.g. journal exports.
+type DriveFileWriter struct {
+	Log *zap.SugaredLogger
+}
+
+func (w *DriveFileWriter) WriteFile(ctx context.Context, accessToken string, folderID string, filename string, content string) error {
+	fileService, e := NewExportFileService(ctx, accessToken, folderID, filename, w.Log)
+	if e != nil {
+		return e
+	}
+	return fileService.Upload(ctx, content)
+}
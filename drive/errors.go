@@ -1,30 +1,97 @@
 package drive
 
 import (
+	"context"
+	stderrors "errors"
+	"strings"
+
 	journalskill "github.com/petergtz/alexa-journal"
+	j "github.com/petergtz/alexa-journal/journal"
 	r "github.com/petergtz/alexa-journal/locale/resources"
 	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
 )
 
 type DriveSheetErrorInterpreter struct {
 	ErrorReporter journalskill.ErrorReporter
 }
 
-func (interpreter *DriveSheetErrorInterpreter) Interpret(e error, l journalskill.Localizer) string {
-	cause := errors.Cause(e)
+func (interpreter *DriveSheetErrorInterpreter) Interpret(ctx context.Context, e error, l journalskill.Localizer) string {
+	cause := Classify(errors.Cause(e))
 	switch {
+	case IsAuthExpiredError(cause):
+		return l.Get(r.DriveAuthExpiredError)
+	case IsPermissionDeniedError(cause):
+		return l.Get(r.DrivePermissionDeniedError)
+	case IsRateLimitedError(cause):
+		return l.Get(r.DriveRateLimitedError)
+	case IsUnavailableError(cause):
+		return l.Get(r.DriveUnavailableError)
 	case IsCannotCreateFileError(cause):
 		return l.Get(r.DriveCannotCreateFileError)
 	case IsMultipleFilesFoundError(cause):
 		return l.Get(r.DriveMultipleFilesFoundError)
+	case IsSplitJournalError(cause):
+		return l.Get(r.DriveSplitJournalError)
 	case IsSheetNotFoundError(cause):
 		return l.Get(r.DriveSheetNotFoundError)
+	case IsNotFoundError(cause):
+		return l.Get(r.DriveJournalNotFoundError)
+	case j.IsEntryNotFoundError(cause):
+		return l.Get(r.EntryNotFoundError)
+	case j.IsMalformedRowsError(cause):
+		return l.Get(r.SomeEntriesCouldNotBeRead)
 	default:
-		interpreter.ErrorReporter.ReportError(errors.Wrap(e, "Could not interpret this error."))
+		interpreter.ErrorReporter.ReportError(ctx, errors.Wrap(e, "Could not interpret this error."))
 		return l.Get(r.DriveUnknownError)
 	}
 }
 
+func (interpreter *DriveSheetErrorInterpreter) RequiresAccountLinking(e error) bool {
+	cause := Classify(errors.Cause(e))
+	return IsAuthExpiredError(cause) || IsPermissionDeniedError(cause)
+}
+
+func (interpreter *DriveSheetErrorInterpreter) IsNotFound(e error) bool {
+	return IsNotFoundError(Classify(errors.Cause(e)))
+}
+
+// Classify turns a *googleapi.Error into one of the typed errors below, based on its HTTP status code and reason.
+// Calls that ran out of time are Unavailable as well. Any other error is returned unchanged.
+func Classify(e error) error {
+	if stderrors.Is(e, context.DeadlineExceeded) {
+		return NewUnavailableError(e)
+	}
+	apiError, ok := e.(*googleapi.Error)
+	if !ok {
+		return e
+	}
+	switch {
+	case apiError.Code == 401:
+		return NewAuthExpiredError(apiError)
+	case apiError.Code == 429 || (apiError.Code == 403 && hasRateLimitReason(apiError)):
+		return NewRateLimitedError(apiError)
+	case apiError.Code == 403:
+		return NewPermissionDeniedError(apiError)
+	case apiError.Code == 404:
+		return NewNotFoundError(apiError)
+	case apiError.Code >= 500:
+		return NewUnavailableError(apiError)
+	default:
+		return e
+	}
+}
+
+func hasRateLimitReason(apiError *googleapi.Error) bool {
+	for _, item := range apiError.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded", "dailyLimitExceeded", "quotaExceeded":
+			return true
+		}
+	}
+	return false
+}
+
 type CannotCreateFileError struct{ error }
 
 func NewCannotCreateFileError(filename string, cause error) *CannotCreateFileError {
@@ -35,16 +102,53 @@ func IsCannotCreateFileError(e error) bool {
 	return is
 }
 
-type MultipleFilesFoundError struct{ error }
+type FileNotFoundError struct{ error }
+
+func NewFileNotFoundError(filename string) *FileNotFoundError {
+	return &FileNotFoundError{errors.Errorf("FileNotFoundError. filename: %v", filename)}
+}
+func IsFileNotFoundError(e error) bool {
+	_, is := e.(*FileNotFoundError)
+	return is
+}
+
+type MultipleFilesFoundError struct {
+	error
+	candidates []journalskill.JournalFile
+}
 
-func NewMultipleFilesFoundError(filename string) *MultipleFilesFoundError {
-	return &MultipleFilesFoundError{errors.Errorf("MultipleFilesFoundError. filename: %v", filename)}
+func NewMultipleFilesFoundError(filename string, candidates []journalskill.JournalFile) *MultipleFilesFoundError {
+	return &MultipleFilesFoundError{errors.Errorf("MultipleFilesFoundError. filename: %v", filename), candidates}
 }
+
+// Candidates returns the files found, so that the user can choose one of them.
+func (e *MultipleFilesFoundError) Candidates() []journalskill.JournalFile { return e.candidates }
 func IsMultipleFilesFoundError(e error) bool {
 	_, is := e.(*MultipleFilesFoundError)
 	return is
 }
 
+type SplitJournalError struct {
+	error
+	parts []journalskill.JournalFile
+}
+
+func NewSplitJournalError(parts []journalskill.JournalFile) *SplitJournalError {
+	var names []string
+	for _, part := range parts {
+		names = append(names, part.Name)
+	}
+	return &SplitJournalError{errors.Errorf("SplitJournalError. filenames: %v", strings.Join(names, ", ")), parts}
+}
+
+// Parts returns the spreadsheets the journal's entries are in, so that the user can merge them. The first one is the
+// one to merge the others into.
+func (e *SplitJournalError) Parts() []journalskill.JournalFile { return e.parts }
+func IsSplitJournalError(e error) bool {
+	_, is := e.(*SplitJournalError)
+	return is
+}
+
 type SheetNotFoundError struct{ error }
 
 func NewSheetNotFoundError(sheetsTitle string) *SheetNotFoundError {
@@ -54,3 +158,55 @@ func IsSheetNotFoundError(e error) bool {
 	_, is := e.(*SheetNotFoundError)
 	return is
 }
+
+type AuthExpiredError struct{ error }
+
+func NewAuthExpiredError(cause error) *AuthExpiredError {
+	return &AuthExpiredError{errors.Errorf("AuthExpiredError. cause: %v", cause.Error())}
+}
+func IsAuthExpiredError(e error) bool {
+	_, is := e.(*AuthExpiredError)
+	return is
+}
+
+type PermissionDeniedError struct{ error }
+
+func NewPermissionDeniedError(cause error) *PermissionDeniedError {
+	return &PermissionDeniedError{errors.Errorf("PermissionDeniedError. cause: %v", cause.Error())}
+}
+func IsPermissionDeniedError(e error) bool {
+	_, is := e.(*PermissionDeniedError)
+	return is
+}
+
+type RateLimitedError struct{ error }
+
+func NewRateLimitedError(cause error) *RateLimitedError {
+	return &RateLimitedError{errors.Errorf("RateLimitedError. cause: %v", cause.Error())}
+}
+func IsRateLimitedError(e error) bool {
+	_, is := e.(*RateLimitedError)
+	return is
+}
+
+// NotFoundError means that a file doesn't exist anymore or isn't shared with the user anymore. Google doesn't tell
+// these apart.
+type NotFoundError struct{ error }
+
+func NewNotFoundError(cause error) *NotFoundError {
+	return &NotFoundError{errors.Errorf("NotFoundError. cause: %v", cause.Error())}
+}
+func IsNotFoundError(e error) bool {
+	_, is := e.(*NotFoundError)
+	return is
+}
+
+type UnavailableError struct{ error }
+
+func NewUnavailableError(cause error) *UnavailableError {
+	return &UnavailableError{errors.Errorf("UnavailableError. cause: %v", cause.Error())}
+}
+func IsUnavailableError(e error) bool {
+	_, is := e.(*UnavailableError)
+	return is
+}
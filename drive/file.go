@@ -2,8 +2,10 @@ package drive
 
 import (
 	"context"
-	"fmt"
+	"strings"
+	"time"
 
+	journalskill "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/util"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -22,8 +24,18 @@ func newDriveService(accessToken string) *drive.Service {
 	return driveService
 }
 
-func fileIDFrom(files *drive.FilesService, filename string, log *zap.SugaredLogger) (fileID string, err error) {
-	fileList, e := files.List().Q("name = '" + filename + "' and trashed = false").Do()
+// fileIDFrom returns the ID of the file with the given name, or an empty string if there is none. If folderID is set,
+// only that folder is searched.
+func fileIDFrom(ctx context.Context, files *drive.FilesService, filename string, folderID string, log *zap.SugaredLogger) (fileID string, err error) {
+	query := "name = " + queryString(filename) + " and trashed = false"
+	if folderID != "" {
+		query += " and " + queryString(folderID) + " in parents"
+	}
+	var fileList *drive.FileList
+	e := withRetries(ctx, log, "drive.files.list", isTransient, func(ctx context.Context) (e error) {
+		fileList, e = files.List().Q(query).Fields("files(id, name, modifiedTime)").Context(ctx).Do()
+		return
+	})
 	if e != nil {
 		return "", errors.Wrap(e, "Could not list files")
 	}
@@ -34,12 +46,57 @@ func fileIDFrom(files *drive.FilesService, filename string, log *zap.SugaredLogg
 		log.Infof("File %v already exists. Using it.", filename)
 		return fileList.Files[0].Id, nil
 	default:
-		var filenames []string
-		for _, file := range fileList.Files {
-			filenames = append(filenames, fmt.Sprintf("%#v", file.Name))
-		}
-		return "", NewMultipleFilesFoundError(filename)
+		return "", NewMultipleFilesFoundError(filename, journalFilesFrom(fileList.Files))
+	}
+}
+
+// folderIDFrom returns the ID of the folder with the given name, or an empty string if there is none.
+func folderIDFrom(ctx context.Context, files *drive.FilesService, folderName string, log *zap.SugaredLogger) (string, error) {
+	var fileList *drive.FileList
+	e := withRetries(ctx, log, "drive.files.list", isTransient, func(ctx context.Context) (e error) {
+		fileList, e = files.List().
+			Q("name = " + queryString(folderName) + " and mimeType = '" + folderMimeType + "' and trashed = false").
+			Fields("files(id, name, modifiedTime)").
+			Context(ctx).Do()
+		return
+	})
+	if e != nil {
+		return "", errors.Wrap(e, "Could not list folders")
+	}
+	switch len(fileList.Files) {
+	case 0:
+		return "", nil
+	case 1:
+		return fileList.Files[0].Id, nil
+	default:
+		return "", NewMultipleFilesFoundError(folderName, journalFilesFrom(fileList.Files))
+	}
+}
+
+const folderMimeType = "application/vnd.google-apps.folder"
+
+// moveToFolder moves the file with fileID from the root of the user's Drive, where the Sheets API creates
+// spreadsheets, to the folder with folderID.
+func moveToFolder(ctx context.Context, files *drive.FilesService, fileID string, folderID string, log *zap.SugaredLogger) error {
+	e := withRetries(ctx, log, "drive.files.update", isTransient, func(ctx context.Context) error {
+		_, e := files.Update(fileID, &drive.File{}).AddParents(folderID).RemoveParents("root").Context(ctx).Do()
+		return e
+	})
+	return errors.Wrapf(e, "Could not move file %v to folder %v", fileID, folderID)
+}
+
+// queryString quotes s for Drive search queries.
+func queryString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func journalFilesFrom(files []*drive.File) []journalskill.JournalFile {
+	var journalFiles []journalskill.JournalFile
+	for _, file := range files {
+		modifiedTime, _ := time.Parse(time.RFC3339, file.ModifiedTime)
+		journalFiles = append(journalFiles, journalskill.JournalFile{ID: file.Id, ModifiedTime: modifiedTime})
 	}
+	return journalFiles
 }
 
 func DeleteFile(accessToken string, fileID string) {
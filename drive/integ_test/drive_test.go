@@ -1,6 +1,7 @@
 package drive_test
 
 import (
+	"context"
 	"os"
 
 	"go.uber.org/zap"
@@ -14,6 +15,7 @@ var _ = Describe("Drive", func() {
 	var (
 		token string
 		log   *zap.Logger
+		ctx   = context.Background()
 	)
 
 	BeforeSuite(func() {
@@ -28,14 +30,14 @@ var _ = Describe("Drive", func() {
 
 	Describe("FileService", func() {
 		It("can download and upload content", func() {
-			fileService, e := drive.NewFileService(token, "journal-test", log.Sugar())
+			fileService, e := drive.NewFileService(ctx, token, "journal-test", log.Sugar())
 			Expect(e).NotTo(HaveOccurred())
 			defer drive.DeleteFile(token, fileService.FileID)
 
-			content, e := fileService.Download()
+			content, e := fileService.Download(ctx)
 			Expect(e).NotTo(HaveOccurred())
-			fileService.Upload(content + "\nanother line here")
-			content2, e := fileService.Download()
+			fileService.Upload(ctx, content+"\nanother line here")
+			content2, e := fileService.Download(ctx)
 			Expect(e).NotTo(HaveOccurred())
 
 			Expect(content2).To(Equal(content + "\nanother line here"))
@@ -44,16 +46,16 @@ var _ = Describe("Drive", func() {
 
 	Describe("SheetBasedTabularData", func() {
 		It("can append rows and read rows", func() {
-			sheetsService, e := drive.NewSheetBasedTabularData(token, "journal-test", "my-sheet", log.Sugar())
+			sheetsService, e := drive.NewSheetBasedTabularData(ctx, token, "journal-test", "my-sheet", log.Sugar())
 			Expect(e).NotTo(HaveOccurred())
 			defer drive.DeleteFile(token, sheetsService.SpreadsheetID)
 
-			e = sheetsService.AppendRow([]string{"a", "b", "c"})
+			e = sheetsService.AppendRow(ctx, []string{"a", "b", "c"})
 			Expect(e).NotTo(HaveOccurred())
-			e = sheetsService.AppendRow([]string{"d", "e", "f"})
+			e = sheetsService.AppendRow(ctx, []string{"d", "e", "f"})
 			Expect(e).NotTo(HaveOccurred())
 
-			Expect(sheetsService.Rows()).To(Equal([][]string{
+			Expect(sheetsService.Rows(ctx)).To(Equal([][]string{
 				[]string{"a", "b", "c"},
 				[]string{"d", "e", "f"},
 			}))
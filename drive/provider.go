@@ -1,42 +1,146 @@
 package drive
 
 import (
+	"context"
+	"strings"
 	"time"
 
+	journalskill "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/search/custom"
 
 	"github.com/patrickmn/go-cache"
 	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/metrics"
 
 	"go.uber.org/zap"
 )
 
 type DriveSheetJournalProvider struct {
-	Log   *zap.SugaredLogger
-	cache *cache.Cache
+	Log     *zap.SugaredLogger
+	Metrics metrics.Metrics
+	cache   *cache.Cache
 }
 
-func NewDriveSheetJournalProvider(log *zap.SugaredLogger) *DriveSheetJournalProvider {
+func NewDriveSheetJournalProvider(log *zap.SugaredLogger, m metrics.Metrics) *DriveSheetJournalProvider {
 	return &DriveSheetJournalProvider{
-		Log:   log,
-		cache: cache.New(time.Hour, time.Hour),
+		Log:     log,
+		Metrics: m,
+		cache:   cache.New(time.Hour, time.Hour),
 	}
 }
 
-func (jp *DriveSheetJournalProvider) Get(accessToken string, spreadsheetName string) (j.Journal, error) {
-	tabData, exists := jp.cache.Get(accessToken)
-	if !exists {
+// Get returns the journal at location. Unless the location has a spreadsheet ID, the spreadsheet is looked up by name
+// and created if necessary. Spreadsheets are cached per access token and location, so that users with several
+// journals don't look them up on every request. Spreadsheets that turned out to be gone are looked up again, so that
+// the caller gets a *NotFoundError instead of a journal that fails on every call.
+func (jp *DriveSheetJournalProvider) Get(ctx context.Context, accessToken string, location journalskill.JournalLocation) (j.Journal, error) {
+	cacheKey := strings.Join([]string{accessToken, location.SpreadsheetID, location.FolderID, location.SpreadsheetName}, "\x00")
+	tabData, exists := jp.cache.Get(cacheKey)
+	if !exists || tabData.(*SheetBasedTabularData).isGone() {
 		var e error
-		tabData, e = NewSheetBasedTabularData(accessToken, spreadsheetName, spreadsheetName, jp.Log)
+		tabData, e = jp.tabularDataAt(ctx, accessToken, location)
 		if e != nil {
 			return j.Journal{}, e
 		}
 	}
 
-	jp.cache.SetDefault(accessToken, tabData)
+	jp.cache.SetDefault(cacheKey, tabData)
 
+	index := custom.NewSearchIndex(jp.Log)
+	index.Metrics = jp.Metrics
 	return j.Journal{
-		Data:  tabData.(*SheetBasedTabularData),
-		Index: custom.NewSearchIndex(jp.Log),
+		Data:      tabData.(*SheetBasedTabularData),
+		Index:     index,
+		StorageID: tabData.(*SheetBasedTabularData).SpreadsheetID,
 	}, nil
 }
+
+// tabularDataAt finds the spreadsheet at location. A spreadsheet with one of the location's alternative names is only
+// used if there is none with its spreadsheet name. If there are entries under several names, the user has to decide
+// whether to merge them into the one with the spreadsheet name or, if that one is empty, the one with the most entries.
+func (jp *DriveSheetJournalProvider) tabularDataAt(ctx context.Context, accessToken string, location journalskill.JournalLocation) (*SheetBasedTabularData, error) {
+	if location.SpreadsheetID != "" {
+		return jp.open(ctx, accessToken, location.SpreadsheetID, location.SpreadsheetName)
+	}
+	files := newDriveService(accessToken).Files
+	var found []journalskill.JournalFile
+	for _, name := range append([]string{location.SpreadsheetName}, location.AlternativeNames...) {
+		id, e := fileIDFrom(ctx, files, name, location.FolderID, jp.Log)
+		if e != nil {
+			return nil, e
+		}
+		if id != "" {
+			found = append(found, journalskill.JournalFile{ID: id, Name: name})
+		}
+	}
+	switch len(found) {
+	case 0:
+		tabData, e := NewSheetBasedTabularDataInFolder(ctx, accessToken, location.FolderID, location.SpreadsheetName, location.SpreadsheetName, jp.Log)
+		if e != nil {
+			return nil, e
+		}
+		tabData.Metrics = jp.Metrics
+		return tabData, nil
+	case 1:
+		return jp.open(ctx, accessToken, found[0].ID, found[0].Name)
+	}
+
+	// Spreadsheets without entries don't count, e.g. one that was created when the user first used another language.
+	var parts []journalskill.JournalFile
+	var partsData []*SheetBasedTabularData
+	var counts []int
+	for _, file := range found {
+		tabData, e := jp.open(ctx, accessToken, file.ID, file.Name)
+		if e != nil {
+			return nil, e
+		}
+		count, e := j.CountEntries(ctx, tabData)
+		if e != nil {
+			return nil, e
+		}
+		if count > 0 {
+			parts = append(parts, file)
+			partsData = append(partsData, tabData)
+			counts = append(counts, count)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return jp.open(ctx, accessToken, found[0].ID, found[0].Name)
+	case 1:
+		return partsData[0], nil
+	default:
+		return nil, NewSplitJournalError(withMergeTargetFirst(parts, counts, location.SpreadsheetName))
+	}
+}
+
+// withMergeTargetFirst moves the part the others should be merged into to the front: the one named name or, if that
+// one has no entries, the one with the most entries.
+func withMergeTargetFirst(parts []journalskill.JournalFile, counts []int, name string) []journalskill.JournalFile {
+	target := 0
+	for i, part := range parts {
+		if part.Name == name {
+			target = i
+			break
+		}
+		if counts[i] > counts[target] {
+			target = i
+		}
+	}
+	result := append([]journalskill.JournalFile{parts[target]}, parts[:target]...)
+	return append(result, parts[target+1:]...)
+}
+
+// open uses the spreadsheet with spreadsheetID. Its sheet is preferably the one with sheetTitle.
+func (jp *DriveSheetJournalProvider) open(ctx context.Context, accessToken string, spreadsheetID string, sheetTitle string) (*SheetBasedTabularData, error) {
+	tabData := OpenSheetBasedTabularData(accessToken, spreadsheetID, sheetTitle, jp.Log)
+	tabData.Metrics = jp.Metrics
+	if e := tabData.resolveSheetTitle(ctx); e != nil {
+		return nil, e
+	}
+	return tabData, nil
+}
+
+func (jp *DriveSheetJournalProvider) FolderID(ctx context.Context, accessToken string, folderName string) (string, error) {
+	return folderIDFrom(ctx, newDriveService(accessToken).Files, folderName, jp.Log)
+}
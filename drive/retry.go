@@ -0,0 +1,74 @@
+package drive
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/petergtz/alexa-journal/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const (
+	maxAttempts    = 4
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 2 * time.Second
+)
+
+// retryable tells whether a failed call may be tried again.
+type retryable func(e error) bool
+
+// isTransient is used for reads and idempotent writes: These can safely be repeated
+// whenever Google asks to slow down or the service or network had a hiccup.
+func isTransient(e error) bool {
+	cause := Classify(errors.Cause(e))
+	if IsRateLimitedError(cause) || IsUnavailableError(cause) {
+		return true
+	}
+	netError, ok := cause.(net.Error)
+	return ok && netError.Timeout()
+}
+
+// isRateLimited is used for writes that are not idempotent, like appending rows. When a request got rate limited,
+// it was not processed, so repeating it doesn't risk duplicates. Other errors could have happened after the
+// write was applied.
+func isRateLimited(e error) bool {
+	return IsRateLimitedError(Classify(errors.Cause(e)))
+}
+
+// withRetries calls call until it succeeds, fails with an error that shouldRetry rejects, or maxAttempts is reached.
+// Between attempts it waits with exponential backoff and full jitter. It never waits beyond ctx's deadline.
+// All attempts together are traced as one span named operation. call gets the span's context, so that the API
+// requests it makes belong to that span.
+func withRetries(ctx context.Context, log *zap.SugaredLogger, operation string, shouldRetry retryable, call func(ctx context.Context) error) (err error) {
+	ctx, span := tracing.Start(ctx, operation)
+	defer func() { tracing.End(span, err) }()
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		span.SetAttributes(attribute.Int("attempts", attempt))
+		e := call(ctx)
+		if e == nil || attempt == maxAttempts || ctx.Err() != nil || !shouldRetry(e) {
+			return e
+		}
+		wait := time.Duration(rand.Int63n(int64(backoff)))
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return e
+		}
+		if log != nil {
+			log.Infow("Retrying Google API call", "attempt", attempt, "wait", wait, "error", e)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return e
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
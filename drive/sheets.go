@@ -2,7 +2,11 @@ package drive
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/petergtz/alexa-journal/metrics"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
@@ -13,34 +17,75 @@ type SheetBasedTabularData struct {
 	Service       *sheets.Service
 	Log           *zap.SugaredLogger
 	SpreadsheetID string
-	sheetTitle    string
+	// Metrics, if set, records latency and outcome of the Sheets API calls made after construction.
+	Metrics    metrics.Metrics
+	sheetTitle string
+	// gone is set to 1 once a call found the spreadsheet to be deleted or not shared anymore.
+	gone int32
 }
 
-func NewSheetBasedTabularData(accessToken string, filename string, sheetTitle string, log *zap.SugaredLogger) (*SheetBasedTabularData, error) {
+func NewSheetBasedTabularData(ctx context.Context, accessToken string, filename string, sheetTitle string, log *zap.SugaredLogger) (*SheetBasedTabularData, error) {
+	return NewSheetBasedTabularDataInFolder(ctx, accessToken, "", filename, sheetTitle, log)
+}
+
+// NewSheetBasedTabularDataInFolder works like NewSheetBasedTabularData, but only looks for the spreadsheet in the
+// folder with folderID and creates it there. An empty folderID means anywhere in the user's Drive.
+func NewSheetBasedTabularDataInFolder(ctx context.Context, accessToken string, folderID string, filename string, sheetTitle string, log *zap.SugaredLogger) (*SheetBasedTabularData, error) {
 	sheetsService := newSheetsService(accessToken)
-	spreadsheetID, e := fileIDFrom(newDriveService(accessToken).Files, filename, log)
+	driveService := newDriveService(accessToken)
+	spreadsheetID, e := fileIDFrom(ctx, driveService.Files, filename, folderID, log)
 	if e != nil {
 		return nil, e
 	}
 	if spreadsheetID == "" {
 		log.Infof("Spreadsheet %v does not exist. Creating it.", filename)
-		ss, e := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
-			Properties: &sheets.SpreadsheetProperties{Title: filename},
-			Sheets: []*sheets.Sheet{&sheets.Sheet{
-				Properties: &sheets.SheetProperties{Title: sheetTitle},
-			}},
-		}).Do()
+		var ss *sheets.Spreadsheet
+		e := withRetries(ctx, log, "sheets.spreadsheets.create", isRateLimited, func(ctx context.Context) (e error) {
+			ss, e = sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+				Properties: &sheets.SpreadsheetProperties{Title: filename},
+				Sheets: []*sheets.Sheet{&sheets.Sheet{
+					Properties: &sheets.SheetProperties{Title: sheetTitle},
+				}},
+			}).Context(ctx).Do()
+			return
+		})
 		if e != nil {
+			if classified := Classify(e); classified != e {
+				return nil, classified
+			}
 			return nil, NewCannotCreateFileError(filename, e)
 		}
 		spreadsheetID = ss.SpreadsheetId
+		if folderID != "" {
+			if e := moveToFolder(ctx, driveService.Files, spreadsheetID, folderID, log); e != nil {
+				return nil, Classify(errors.Cause(e))
+			}
+		}
+	}
+	return OpenSheetBasedTabularData(accessToken, spreadsheetID, sheetTitle, log), nil
+}
+
+// FindSheetBasedTabularData works like NewSheetBasedTabularData, but returns a *FileNotFoundError instead of creating
+// the spreadsheet if there is none with filename.
+func FindSheetBasedTabularData(ctx context.Context, accessToken string, filename string, sheetTitle string, log *zap.SugaredLogger) (*SheetBasedTabularData, error) {
+	spreadsheetID, e := fileIDFrom(ctx, newDriveService(accessToken).Files, filename, "", log)
+	if e != nil {
+		return nil, e
+	}
+	if spreadsheetID == "" {
+		return nil, NewFileNotFoundError(filename)
 	}
+	return OpenSheetBasedTabularData(accessToken, spreadsheetID, sheetTitle, log), nil
+}
+
+// OpenSheetBasedTabularData uses the spreadsheet with the given ID without looking it up.
+func OpenSheetBasedTabularData(accessToken string, spreadsheetID string, sheetTitle string, log *zap.SugaredLogger) *SheetBasedTabularData {
 	return &SheetBasedTabularData{
-		Service:       sheetsService,
+		Service:       newSheetsService(accessToken),
 		Log:           log,
 		SpreadsheetID: spreadsheetID,
 		sheetTitle:    sheetTitle,
-	}, nil
+	}
 }
 
 func newSheetsService(accessToken string) *sheets.Service {
@@ -55,25 +100,83 @@ func newSheetsService(accessToken string) *sheets.Service {
 	return sh
 }
 
-func (td *SheetBasedTabularData) AppendRow(row []string) error {
-	if len(row) != 3 {
-		panic(errors.Errorf("Currently only rows with 3 cells are supported. Given: %v", row))
+func (td *SheetBasedTabularData) AppendRow(ctx context.Context, row []string) error {
+	e := td.call(ctx, "append_row", isRateLimited, func(ctx context.Context) error {
+		_, e := td.Service.Spreadsheets.Values.Append(td.SpreadsheetID, td.sheetTitle+"!A1", &sheets.ValueRange{
+			Values: [][]interface{}{interfaceRowFrom(row)},
+		}).ValueInputOption("USER_ENTERED").Context(ctx).Do()
+		return e
+	})
+	if e != nil {
+		return errors.Wrap(e, "Could not append row to spreadsheet")
 	}
-	interfaceRow := make([]interface{}, len(row))
-	for i, cell := range row {
-		interfaceRow[i] = cell
+	return nil
+}
+
+func (td *SheetBasedTabularData) AppendRows(ctx context.Context, rows [][]string) error {
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = interfaceRowFrom(row)
 	}
-	_, e := td.Service.Spreadsheets.Values.Append(td.SpreadsheetID, td.sheetTitle+"!A1:C1", &sheets.ValueRange{
-		Values: [][]interface{}{interfaceRow},
-	}).ValueInputOption("USER_ENTERED").Do()
+	e := td.call(ctx, "append_rows", isRateLimited, func(ctx context.Context) error {
+		_, e := td.Service.Spreadsheets.Values.Append(td.SpreadsheetID, td.sheetTitle+"!A1", &sheets.ValueRange{
+			Values: values,
+		}).ValueInputOption("USER_ENTERED").Context(ctx).Do()
+		return e
+	})
 	if e != nil {
-		return errors.Wrapf(e, "Could not append values %v to spreadhseet", row)
+		return errors.Wrapf(e, "Could not append %v rows to spreadsheet", len(rows))
 	}
 	return nil
 }
 
-func (td *SheetBasedTabularData) Rows() ([][]string, error) {
-	resp, e := td.Service.Spreadsheets.Values.Get(td.SpreadsheetID, td.sheetTitle).Do()
+func (td *SheetBasedTabularData) UpdateRows(ctx context.Context, rows map[int][]string) error {
+	var valueRanges []*sheets.ValueRange
+	for rowNum, row := range rows {
+		valueRanges = append(valueRanges, &sheets.ValueRange{
+			Range:  fmt.Sprintf("%v!A%v", td.sheetTitle, rowNum+1),
+			Values: [][]interface{}{interfaceRowFrom(row)},
+		})
+	}
+	e := td.call(ctx, "update_rows", isTransient, func(ctx context.Context) error {
+		_, e := td.Service.Spreadsheets.Values.BatchUpdate(td.SpreadsheetID, &sheets.BatchUpdateValuesRequest{
+			Data:             valueRanges,
+			ValueInputOption: "USER_ENTERED",
+		}).Context(ctx).Do()
+		return e
+	})
+	if e != nil {
+		return errors.Wrapf(e, "Could not update %v rows in spreadsheet", len(rows))
+	}
+	return nil
+}
+
+// call makes a Sheets API call with retries, traces it and records it as operation.
+func (td *SheetBasedTabularData) call(ctx context.Context, operation string, shouldRetry retryable, call func(ctx context.Context) error) error {
+	start := time.Now()
+	e := withRetries(ctx, td.Log, "sheets."+operation, shouldRetry, call)
+	metrics.Measure(td.Metrics, "sheets_api_call", start, e, metrics.Dimensions{"operation": operation})
+	if IsNotFoundError(Classify(e)) {
+		atomic.StoreInt32(&td.gone, 1)
+	}
+	return e
+}
+
+// isGone tells whether a call found the spreadsheet to be deleted or not shared anymore.
+func (td *SheetBasedTabularData) isGone() bool {
+	return atomic.LoadInt32(&td.gone) == 1
+}
+
+func interfaceRowFrom(row []string) []interface{} {
+	interfaceRow := make([]interface{}, len(row))
+	for i, cell := range row {
+		interfaceRow[i] = cell
+	}
+	return interfaceRow
+}
+
+func (td *SheetBasedTabularData) Rows(ctx context.Context) ([][]string, error) {
+	resp, e := td.values(ctx)
 	if e != nil {
 		return nil, errors.Wrapf(e, "Could not get values")
 	}
@@ -90,22 +193,30 @@ func (td *SheetBasedTabularData) Rows() ([][]string, error) {
 	return result, nil
 }
 
-func (td *SheetBasedTabularData) Empty() (bool, error) {
-	resp, e := td.Service.Spreadsheets.Values.Get(td.SpreadsheetID, td.sheetTitle).Do()
+func (td *SheetBasedTabularData) Empty(ctx context.Context) (bool, error) {
+	resp, e := td.values(ctx)
 	if e != nil {
 		return false, errors.Wrapf(e, "Could not get values")
 	}
 	return len(resp.Values) == 0, nil
 }
 
-func (td *SheetBasedTabularData) DeleteRow(rowNum int) error {
+func (td *SheetBasedTabularData) values(ctx context.Context) (resp *sheets.ValueRange, err error) {
+	err = td.call(ctx, "get_values", isTransient, func(ctx context.Context) (e error) {
+		resp, e = td.Service.Spreadsheets.Values.Get(td.SpreadsheetID, td.sheetTitle).Context(ctx).Do()
+		return
+	})
+	return
+}
+
+func (td *SheetBasedTabularData) DeleteRow(ctx context.Context, rowNum int) error {
 	td.Log.Debugw("DeleteRow", "row-num", rowNum)
-	resp, e := td.Service.Spreadsheets.Get(td.SpreadsheetID).Fields("sheets.properties").Do()
+	sheetList, e := td.sheetList(ctx)
 	if e != nil {
-		return errors.Wrapf(e, "Could not get sheets properties")
+		return e
 	}
 	var sheetID int64 = -1
-	for _, sheet := range resp.Sheets {
+	for _, sheet := range sheetList {
 		if sheet.Properties.Title == td.sheetTitle {
 			sheetID = sheet.Properties.SheetId
 			break
@@ -115,22 +226,59 @@ func (td *SheetBasedTabularData) DeleteRow(rowNum int) error {
 		return NewSheetNotFoundError(td.sheetTitle)
 	}
 	td.Log.Debugw("DeleteRow", "sheet-id", sheetID)
-	_, e = td.Service.Spreadsheets.BatchUpdate(td.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{
-			&sheets.Request{
-				DeleteDimension: &sheets.DeleteDimensionRequest{
-					Range: &sheets.DimensionRange{
-						SheetId:    sheetID,
-						Dimension:  "ROWS",
-						StartIndex: int64(rowNum),
-						EndIndex:   int64(rowNum + 1),
+	// Deleting a row is not idempotent: Repeating it after it went through would delete the next row.
+	e = td.call(ctx, "delete_row", isRateLimited, func(ctx context.Context) error {
+		_, e := td.Service.Spreadsheets.BatchUpdate(td.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				&sheets.Request{
+					DeleteDimension: &sheets.DeleteDimensionRequest{
+						Range: &sheets.DimensionRange{
+							SheetId:    sheetID,
+							Dimension:  "ROWS",
+							StartIndex: int64(rowNum),
+							EndIndex:   int64(rowNum + 1),
+						},
 					},
 				},
 			},
-		},
-	}).Do()
+		}).Context(ctx).Do()
+		return e
+	})
 	if e != nil {
 		return errors.Wrapf(e, "Could not delete row %v", rowNum)
 	}
 	return nil
 }
+
+// resolveSheetTitle keeps the sheet title if the spreadsheet has a sheet with this title and uses the title of its
+// first sheet otherwise. That way, spreadsheets created in another language, whose sheet is named in that language,
+// can still be used.
+func (td *SheetBasedTabularData) resolveSheetTitle(ctx context.Context) error {
+	sheetList, e := td.sheetList(ctx)
+	if e != nil {
+		return e
+	}
+	if len(sheetList) == 0 {
+		return NewSheetNotFoundError(td.sheetTitle)
+	}
+	for _, sheet := range sheetList {
+		if sheet.Properties.Title == td.sheetTitle {
+			return nil
+		}
+	}
+	td.Log.Infow("Sheet not found. Using first sheet instead.", "sheet-title", td.sheetTitle, "first-sheet-title", sheetList[0].Properties.Title)
+	td.sheetTitle = sheetList[0].Properties.Title
+	return nil
+}
+
+func (td *SheetBasedTabularData) sheetList(ctx context.Context) ([]*sheets.Sheet, error) {
+	var resp *sheets.Spreadsheet
+	e := td.call(ctx, "get_sheet_properties", isTransient, func(ctx context.Context) (e error) {
+		resp, e = td.Service.Spreadsheets.Get(td.SpreadsheetID).Fields("sheets.properties").Context(ctx).Do()
+		return
+	})
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not get sheets properties")
+	}
+	return resp.Sheets, nil
+}
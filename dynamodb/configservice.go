@@ -2,23 +2,28 @@ package dynamodb
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	skill "github.com/petergtz/alexa-journal"
+	"github.com/petergtz/alexa-journal/metrics"
+	"github.com/petergtz/alexa-journal/tracing"
 	"github.com/petergtz/alexa-journal/util"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func CreateConfigService(tableName string, region string, errorReporter skill.ErrorReporter) *ConfigService {
+func CreateConfigService(tableName string, region string, errorReporter skill.ErrorReporter, m metrics.Metrics) *ConfigService {
 	c, e := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
 	util.PanicOnError(errors.Wrap(e, "Unable to load SDK config"))
 	return &ConfigService{
 		dynamo:        dynamodb.NewFromConfig(c),
 		tableName:     tableName,
 		errorReporter: errorReporter,
+		metrics:       m,
 	}
 }
 
@@ -26,6 +31,7 @@ type ConfigService struct {
 	dynamo        *dynamodb.Client
 	tableName     string
 	errorReporter skill.ErrorReporter
+	metrics       metrics.Metrics
 }
 
 type record struct {
@@ -33,29 +39,37 @@ type record struct {
 	skill.Config
 }
 
-func (cs *ConfigService) PersistConfig(userID string, config skill.Config) {
+func (cs *ConfigService) PersistConfig(ctx context.Context, userID string, config skill.Config) {
 	r := &record{UserID: userID, Config: config}
 	input, e := attributevalue.MarshalMap(r)
-	util.PanicOnError(errors.Wrapf(e, "Could not marshal ConfigService record %#v", r))
-	_, e = cs.dynamo.PutItem(context.TODO(), &dynamodb.PutItemInput{
+	util.PanicOnError(errors.Wrap(e, "Could not marshal ConfigService record"))
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "dynamodb.PutItem", attribute.String("table", cs.tableName))
+	_, e = cs.dynamo.PutItem(ctx, &dynamodb.PutItemInput{
 		Item:      input,
 		TableName: aws.String(cs.tableName),
 	})
+	tracing.End(span, e)
+	metrics.Measure(cs.metrics, "config_service_call", start, e, metrics.Dimensions{"operation": "persist_config"})
 	if e != nil {
-		cs.errorReporter.ReportError(errors.Wrapf(e, "Could not put item for userID \"%v\" and config \"%#v\"", userID, config))
+		cs.errorReporter.ReportError(ctx, errors.Wrap(e, "Could not put config item"))
 	}
 }
 
-func (cs *ConfigService) GetConfig(userID string) skill.Config {
+func (cs *ConfigService) GetConfig(ctx context.Context, userID string) skill.Config {
 	key, e := attributevalue.MarshalMap(struct{ UserID string }{UserID: userID})
-	util.PanicOnError(errors.Wrapf(e, "Could not marshal UserID %v", userID))
+	util.PanicOnError(errors.Wrap(e, "Could not marshal UserID"))
 
-	output, e := cs.dynamo.GetItem(context.TODO(), &dynamodb.GetItemInput{
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "dynamodb.GetItem", attribute.String("table", cs.tableName))
+	output, e := cs.dynamo.GetItem(ctx, &dynamodb.GetItemInput{
 		Key:       key,
 		TableName: aws.String(cs.tableName),
 	})
+	tracing.End(span, e)
+	metrics.Measure(cs.metrics, "config_service_call", start, e, metrics.Dimensions{"operation": "get_config"})
 	if e != nil {
-		cs.errorReporter.ReportError(errors.Wrapf(e, "Could not get item for key \"%v\"", key))
+		cs.errorReporter.ReportError(ctx, errors.Wrap(e, "Could not get config item"))
 
 		// degrade gracefully to defaults
 		return skill.Config{}
@@ -70,7 +84,7 @@ func (cs *ConfigService) GetConfig(userID string) skill.Config {
 
 	var r record
 	e = attributevalue.UnmarshalMap(output.Item, &r)
-	util.PanicOnError(errors.Wrapf(e, "Could not unmarshal configValue.Item %#v", output.Item))
+	util.PanicOnError(errors.Wrap(e, "Could not unmarshal configValue.Item"))
 
 	return r.Config
 }
@@ -1,12 +1,14 @@
 package dynamodb_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	journalskill "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/dynamodb"
+	"github.com/petergtz/alexa-journal/metrics"
 	"github.com/petergtz/go-alexa"
 )
 
@@ -22,17 +24,17 @@ var _ = Describe("ConfigService", func() {
 		// 	logger.Fatal("env var SECRET_ACCESS_KEY not provided.")
 		// }
 
-		cs := dynamodb.CreateConfigService("TestAlexaJournalConfig", "eu-central-1", &StdOutErrorReporter{})
+		cs := dynamodb.CreateConfigService("TestAlexaJournalConfig", "eu-central-1", &StdOutErrorReporter{}, metrics.Nop{})
 
-		e := cs.PersistConfig("someUserID", journalskill.Config{BeSuccinct: true, ShouldExplainAboutSuccinctMode: false})
+		e := cs.PersistConfig(context.TODO(), "someUserID", journalskill.Config{BeSuccinct: true, ShouldExplainAboutSuccinctMode: false})
 		Expect(e).NotTo(HaveOccurred())
 
-		fmt.Println(cs.GetConfig("someUserID"))
-		fmt.Println(cs.GetConfig("someOtherUserID"))
+		fmt.Println(cs.GetConfig(context.TODO(), "someUserID"))
+		fmt.Println(cs.GetConfig(context.TODO(), "someOtherUserID"))
 	})
 })
 
 type StdOutErrorReporter struct{}
 
-func (*StdOutErrorReporter) ReportError(error)                               {}
-func (*StdOutErrorReporter) ReportPanic(interface{}, *alexa.RequestEnvelope) {}
+func (*StdOutErrorReporter) ReportError(context.Context, error)                               {}
+func (*StdOutErrorReporter) ReportPanic(context.Context, interface{}, *alexa.RequestEnvelope) {}
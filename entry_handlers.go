@@ -0,0 +1,374 @@
+package journalskill
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/locale"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+)
+
+func (h *JournalSkill) newEntry(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	switch in.RequestEnv.Request.DialogState {
+	case "STARTED":
+		return in.Response().Delegate(&intent).Build()
+	case "IN_PROGRESS":
+		switch intent.ConfirmationStatus {
+		case "NONE":
+			return h.draftEntry(in, intent)
+		case "CONFIRMED":
+			date, _, dateType := DateFrom(intent.Slots["date"].Value)
+			if dateType != DayDate {
+				panic(errors.Errorf("Could not parse string '%v' to day date", intent.Slots["date"].Value))
+			}
+
+			text := strings.Join(in.Session.Drafts[intent.Slots["date"].Value], ". ")
+			if prompt, exists := in.Session.Prompts[intent.Slots["date"].Value]; exists {
+				text = prompt + " " + text
+			}
+			id, e := in.Journal.AddEntry(in.Ctx, date, text)
+			if e != nil {
+				return h.errorResponse(in, l.Get(r.NewEntrySaveError, r.ShortPause), e)
+			}
+			in.recordMutation(EntryAdded, id)
+
+			in.Session.Drafting = false
+			delete(in.Session.Drafts, intent.Slots["date"].Value)
+			delete(in.Session.Prompts, intent.Slots["date"].Value)
+			in.Session.EntryIDAwaitingMood = id
+
+			return savedResponse(in, l.Get(r.OkaySaved, r.LongPause)+h.succinctModeExplanation(in.Ctx, in.userID(), in.Config, l))
+		case "DENIED":
+			in.Session.Drafting = false
+			return in.ResponseWithSession().
+				Speak(l.Get(r.OkayNotSaved, r.LongPause) +
+					h.succinctModeExplanation(in.Ctx, in.userID(), in.Config, l) +
+					l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+				Build()
+		default:
+			panic(errors.New("Invalid intent.ConfirmationStatus"))
+		}
+	default:
+		panic(errors.New("Invalid requestEnv.Request.DialogState"))
+	}
+}
+
+// savedResponse tells the user that their entry was saved and asks them how their day was, if they want to be asked.
+// Either way, they can still rate the entry with RateMoodIntent.
+func savedResponse(in *Input, text string) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if !in.Config.AskForMood {
+		return in.ResponseWithSession().Speak(text + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	return in.ResponseWithSession().
+		Speak(text + l.Get(r.LongPause, r.HowWasYourDay)).
+		Reprompt(l.Get(r.HowWasYourDay)).
+		Build()
+}
+
+// draftEntry collects the parts of a new entry until the user says they're done.
+func (h *JournalSkill) draftEntry(in *Input, intent alexa.Intent) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	dateSlotValue := intent.Slots["date"].Value
+	if dateSlotValue == "" {
+		return in.Response().Delegate(&intent).Build()
+	}
+	_, _, dateType := DateFrom(dateSlotValue)
+	if dateType != DayDate {
+		return in.ResponseWithSession().ElicitSlot("date").Speak(l.Get(r.InvalidDate)).Build()
+	}
+	// TODO: could we use intent.Slots["text"].Value == "" instead of !in.Session.Drafting?
+	if _, exists := in.Session.Drafts[dateSlotValue]; exists && !in.Session.Drafting {
+		switch intent.Slots["text"].ConfirmationStatus {
+		case "NONE":
+			text := l.GetTemplated(r.NewEntryDraftExists, map[string]interface{}{
+				"Draft": strings.Join(in.Session.Drafts[dateSlotValue], ". "),
+			})
+			return in.ResponseWithSession().Speak(text).ConfirmSlot("text").Reprompt(text).Build()
+		case "CONFIRMED":
+			break
+		case "DENIED":
+			delete(in.Session.Drafts, dateSlotValue)
+		}
+	}
+	draft := in.Session.Drafts[dateSlotValue]
+	switch strings.ToLower(intent.Slots["text"].Value) {
+	case "":
+		in.Session.Drafting = true
+		dateString := l.GetTemplated(r.ForDate, map[string]interface{}{"Date": dateSlotValue})
+		if prompt := h.promptFor(in.Ctx, dateSlotValue, &in.Session, in.userID(), in.Config, l); prompt != "" {
+			text := l.GetTemplated(r.GuidedPrompt, map[string]interface{}{"ForDate": dateString, "Prompt": prompt})
+			return in.ResponseWithSession().Speak(text).ElicitSlot("text").Reprompt(text).Build()
+		}
+		return in.ResponseWithSession().
+			Speak(l.GetTemplated(r.YouCanNowCreateYourEntry, map[string]interface{}{"ForDate": dateString})).
+			ElicitSlot("text").
+			Reprompt(l.GetTemplated(r.YouCanNowCreateYourEntry_succinct, map[string]interface{}{"ForDate": dateString})).
+			Build()
+	case l.Get(r.Repeat1), l.Get(r.Repeat2):
+		if len(draft) == 0 {
+			return in.Response().Speak(l.Get(r.YourEntryIsEmptyNoRepeat)).ElicitSlot("text").Build()
+		}
+		return in.Response().
+			Speak(l.GetTemplated(r.IRepeat, map[string]interface{}{"Text": draft[len(draft)-1]})).
+			ElicitSlot("text").
+			Build()
+	case l.Get(r.Correct1), l.Get(r.Correct2):
+		if len(draft) == 0 {
+			return in.Response().Speak(l.Get(r.YourEntryIsEmptyNoCorrect)).ElicitSlot("text").Build()
+		}
+		in.Session.Drafts[dateSlotValue] = draft[:len(draft)-1]
+		return in.ResponseWithSession().
+			Speak(l.Get(r.OkayCorrectPart)).
+			ElicitSlot("text").
+			Reprompt(l.Get(r.CorrectPartReprompt)).
+			Build()
+	case l.Get(r.Abort):
+		in.Session.Drafting = false
+		return in.ResponseWithSession().
+			Speak(l.Get(r.NewEntryAborted, r.LongPause) +
+				h.succinctModeExplanation(in.Ctx, in.userID(), in.Config, l) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	case l.Get(r.Done):
+		if len(draft) == 0 {
+			in.Session.Drafting = false
+			return in.ResponseWithSession().
+				Speak(l.Get(r.YourEntryIsEmptyNoSave, r.LongPause) +
+					h.succinctModeExplanation(in.Ctx, in.userID(), in.Config, l) +
+					l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+				Build()
+		}
+		return in.Response().
+			Speak(l.GetTemplated(r.NewEntryConfirmation, map[string]interface{}{
+				"Date": dateSlotValue,
+				"Text": strings.Join(draft, ". "),
+			})).
+			ConfirmIntent(&intent).
+			Reprompt(l.Get(r.NewEntryConfirmationReprompt)).
+			Build()
+	default:
+		in.Session.Drafts[dateSlotValue] = append(draft, intent.Slots["text"].Value)
+		return in.ResponseWithSession().
+			Speak(l.GetTemplated(r.IRepeat, map[string]interface{}{"Text": intent.Slots["text"].Value})).
+			ElicitSlot("text").
+			Reprompt(l.Get(r.NextPartPleaseReprompt)).
+			Build()
+	}
+}
+
+// promptFor returns the guided journaling prompt for the draft of the given date. A draft keeps its prompt, while
+// new drafts get the next prompt of the user's prompt set. It returns an empty string if the user has no prompt set.
+func (h *JournalSkill) promptFor(ctx context.Context, date string, sessionAttributes *SessionAttributes, userID string, config Config, l *locale.Localizer) string {
+	if prompt, exists := sessionAttributes.Prompts[date]; exists {
+		return prompt
+	}
+	prompt := l.Prompt(config.PromptSet, config.NextPromptIndex)
+	if prompt == "" {
+		return ""
+	}
+	sessionAttributes.Prompts[date] = prompt
+	newConfig := config
+	newConfig.NextPromptIndex++
+	h.configService.PersistConfig(ctx, userID, newConfig)
+	return prompt
+}
+
+func (h *JournalSkill) gratitudeList(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	switch in.RequestEnv.Request.DialogState {
+	case "STARTED":
+		return in.Response().Delegate(&intent).Build()
+	case "IN_PROGRESS":
+		if intent.Slots["date"].Value == "" {
+			return in.Response().Delegate(&intent).Build()
+		}
+		draftKey := listDraftKey(intent.Slots["date"].Value)
+		switch intent.ConfirmationStatus {
+		case "NONE":
+			return h.draftGratitudeList(in, intent, draftKey)
+		case "CONFIRMED":
+			date, _, dateType := DateFrom(intent.Slots["date"].Value)
+			if dateType != DayDate {
+				panic(errors.Errorf("Could not parse string '%v' to day date", intent.Slots["date"].Value))
+			}
+
+			id, e := in.Journal.AddListEntry(in.Ctx, date, in.Session.Drafts[draftKey])
+			if e != nil {
+				return h.errorResponse(in, l.Get(r.NewEntrySaveError, r.ShortPause), e)
+			}
+			in.recordMutation(EntryAdded, id)
+
+			delete(in.Session.Drafts, draftKey)
+			in.Session.EntryIDAwaitingMood = id
+
+			return savedResponse(in, l.Get(r.OkaySaved))
+		case "DENIED":
+			delete(in.Session.Drafts, draftKey)
+			return in.ResponseWithSession().Speak(l.Get(r.OkayNotSaved, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+		default:
+			panic(errors.New("Invalid intent.ConfirmationStatus"))
+		}
+	default:
+		panic(errors.New("Invalid requestEnv.Request.DialogState"))
+	}
+}
+
+// draftGratitudeList collects the items of a gratitude list until there are gratitudeListLength of them.
+func (h *JournalSkill) draftGratitudeList(in *Input, intent alexa.Intent, draftKey string) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	_, _, dateType := DateFrom(intent.Slots["date"].Value)
+	if dateType != DayDate {
+		return in.ResponseWithSession().ElicitSlot("date").Speak(l.Get(r.InvalidDate)).Build()
+	}
+	items := in.Session.Drafts[draftKey]
+	var text string
+	switch strings.ToLower(intent.Slots["item"].Value) {
+	case "":
+		text = l.GetTemplated(r.GratitudeListStart, map[string]interface{}{
+			"Count":   gratitudeListLength,
+			"ForDate": l.GetTemplated(r.ForDate, map[string]interface{}{"Date": intent.Slots["date"].Value}),
+		})
+	case l.Get(r.Repeat1), l.Get(r.Repeat2):
+		if len(items) == 0 {
+			text = l.Get(r.GratitudeListEmptyNoRepeat)
+		} else {
+			text = l.GetTemplated(r.GratitudeListRepeatItem, map[string]interface{}{"Text": items[len(items)-1]})
+		}
+	case l.Get(r.Correct1), l.Get(r.Correct2):
+		if len(items) == 0 {
+			text = l.Get(r.GratitudeListEmptyNoCorrect)
+		} else {
+			items = items[:len(items)-1]
+			in.Session.Drafts[draftKey] = items
+			text = l.GetTemplated(r.GratitudeListOkayCorrect, map[string]interface{}{"Number": len(items) + 1})
+		}
+	case l.Get(r.Abort):
+		delete(in.Session.Drafts, draftKey)
+		return in.ResponseWithSession().Speak(l.Get(r.NewEntryAborted, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	default:
+		items = append(items, intent.Slots["item"].Value)
+		in.Session.Drafts[draftKey] = items
+		if len(items) >= gratitudeListLength {
+			return in.ResponseWithSession().
+				Speak(l.GetTemplated(r.GratitudeListConfirmation, map[string]interface{}{
+					"Date":  intent.Slots["date"].Value,
+					"Items": spokenListOf(items, l),
+				})).
+				ConfirmIntent(&intent).
+				Reprompt(l.Get(r.GratitudeListConfirmationReprompt)).
+				Build()
+		}
+		text = l.GetTemplated(r.GratitudeListRepeatItem, map[string]interface{}{"Text": intent.Slots["item"].Value})
+	}
+	itemPrompt := l.GetTemplated(r.GratitudeListItemPrompt, map[string]interface{}{"Number": len(items) + 1})
+	return in.ResponseWithSession().
+		Speak(text + " " + itemPrompt).
+		ElicitSlot("item").
+		Reprompt(itemPrompt).
+		Build()
+}
+
+// listDraftKey is the key of a list entry's draft in SessionAttributes.Drafts. It differs from the key of
+// free text drafts, so the user can draft both kinds of entries for the same date.
+func listDraftKey(dateSlotValue string) string {
+	return "list:" + dateSlotValue
+}
+
+func (h *JournalSkill) deleteEntry(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	switch in.RequestEnv.Request.DialogState {
+	case "STARTED":
+		return in.Response().Delegate(&intent).Build()
+	case "IN_PROGRESS", "COMPLETED":
+		switch intent.ConfirmationStatus {
+		case "NONE":
+			date, _, dateType := DateFrom(intent.Slots["date"].Value)
+			if dateType != DayDate {
+				intent.Slots["date"] = alexa.IntentSlot{
+					Name:               intent.Slots["date"].Name,
+					ConfirmationStatus: intent.Slots["date"].ConfirmationStatus,
+					Resolutions:        intent.Slots["date"].Resolutions,
+					Value:              "",
+				}
+				return in.Response().Delegate(&intent).Build()
+			}
+
+			// Entries written before entries had IDs get one now, so that they can be deleted by it.
+			e := in.Journal.AssignMissingIDs(in.Ctx)
+			if e != nil {
+				return h.errorResponse(in, l.Get(r.DeleteEntryCouldNotGetEntry, r.ShortPause), e)
+			}
+			entries, e := in.Journal.GetEntriesOn(in.Ctx, date)
+			if e != nil {
+				return h.errorResponse(in, l.Get(r.DeleteEntryCouldNotGetEntry, r.ShortPause), e)
+			}
+			if len(entries) == 0 {
+				return in.Response().Speak(l.Get(r.DeleteEntryNotFound)).Build()
+			}
+			// Remember exactly which entries the user confirms, so that rows edited
+			// in the spreadsheet in the meantime can't be deleted by accident.
+			var texts []string
+			in.Session.EntryIDsToDelete = nil
+			for _, entry := range entries {
+				texts = append(texts, entry.EntryText)
+				in.Session.EntryIDsToDelete = append(in.Session.EntryIDsToDelete, entry.ID)
+			}
+			confirmation := l.GetTemplated(r.DeleteEntryConfirmation, map[string]interface{}{"Entry": strings.Join(texts, ". ")})
+			return in.ResponseWithSession().
+				Speak(confirmation).
+				ConfirmIntent(&intent).
+				Reprompt(confirmation).
+				Build()
+		case "CONFIRMED":
+			for _, id := range in.Session.EntryIDsToDelete {
+				e := in.Journal.DeleteEntry(in.Ctx, id)
+				if e != nil {
+					return h.errorResponse(in, l.Get(r.DeleteEntryError, r.ShortPause), e)
+				}
+				in.recordMutation(EntryDeleted, id)
+			}
+			in.Session.EntryIDsToDelete = nil
+			return in.ResponseWithSession().Speak(l.Get(r.OkayDeleted, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+		case "DENIED":
+			in.Session.EntryIDsToDelete = nil
+			return in.ResponseWithSession().Speak(l.Get(r.OkayNotDeleted, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+		default:
+			panic(errors.New("Invalid intent.ConfirmationStatus"))
+		}
+	default:
+		panic(errors.New("Invalid requestEnv.Request.DialogState"))
+	}
+}
+
+func (h *JournalSkill) rateMood(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if in.Session.EntryIDAwaitingMood == "" {
+		return in.Response().Speak(l.Get(r.NoEntryToRate, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	mood, e := strconv.Atoi(in.RequestEnv.Request.Intent.Slots["rating"].Value)
+	if e != nil || mood < j.MinMood || mood > j.MaxMood {
+		return in.Response().
+			Speak(l.Get(r.InvalidMood)).
+			ElicitSlot("rating").
+			Reprompt(l.Get(r.InvalidMood)).
+			Build()
+	}
+	e = in.Journal.SetMood(in.Ctx, in.Session.EntryIDAwaitingMood, mood)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.MoodSaveError, r.ShortPause), e)
+	}
+	in.recordMutation(EntryEdited, in.Session.EntryIDAwaitingMood)
+	in.Session.EntryIDAwaitingMood = ""
+	return in.ResponseWithSession().
+		Speak(l.GetTemplated(r.MoodSaved, map[string]interface{}{"Mood": mood}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
@@ -0,0 +1,13 @@
+package errorreport_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestErrorReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Error Report Suite")
+}
@@ -0,0 +1,96 @@
+package errorreport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxFingerprintFrames is how many stack frames, counted from where the error happened, make up a fingerprint.
+// Frames further out are mostly request dispatching and would only tell apart the ways to get to the same bug.
+const maxFingerprintFrames = 8
+
+// Fingerprint identifies errors that are most likely the same bug: It hashes the type of the error's cause and the
+// functions on the stack where the error happened. Line numbers are left out, so that the fingerprint of a bug
+// survives unrelated changes to the code around it.
+//
+// The stack is taken from the innermost error that has one, as added by github.com/pkg/errors. Otherwise it's the
+// current stack, which during a panic still includes the function that panicked.
+func Fingerprint(e interface{}) string {
+	functions := stackFunctionsOf(e)
+	if len(functions) == 0 {
+		functions = currentStackFunctions()
+	}
+	if len(functions) > maxFingerprintFrames {
+		functions = functions[:maxFingerprintFrames]
+	}
+	hash := sha256.Sum256([]byte(causeTypeOf(e) + "\n" + strings.Join(functions, "\n")))
+	return hex.EncodeToString(hash[:6])
+}
+
+func causeTypeOf(e interface{}) string {
+	if err, isError := e.(error); isError {
+		return fmt.Sprintf("%T", errors.Cause(err))
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+type causer interface {
+	Cause() error
+}
+
+// stackFunctionsOf returns the functions on the stack of the innermost error in e's chain that has a stack.
+func stackFunctionsOf(e interface{}) []string {
+	var stack errors.StackTrace
+	for err, isError := e.(error); isError && err != nil; {
+		if tracer, ok := err.(stackTracer); ok {
+			stack = tracer.StackTrace()
+		}
+		c, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+	var functions []string
+	for _, frame := range stack {
+		// errors.Frame is the program counter + 1, see errors.Frame.pc.
+		if fn := runtime.FuncForPC(uintptr(frame) - 1); fn != nil {
+			functions = append(functions, fn.Name())
+		}
+	}
+	return functions
+}
+
+// currentStackFunctions returns the functions on the current stack, starting where a panic happened, or otherwise
+// where the error was reported from. Frames of the runtime and of this package are left out.
+func currentStackFunctions() []string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	var functions []string
+	for {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			// Everything so far was only handling the panic.
+			functions = nil
+		case strings.HasPrefix(frame.Function, "runtime."),
+			strings.HasPrefix(frame.Function, thisPackage+"."):
+		default:
+			functions = append(functions, frame.Function)
+		}
+		if !more {
+			return functions
+		}
+	}
+}
+
+const thisPackage = "github.com/petergtz/alexa-journal/errorreport"
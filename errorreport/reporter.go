@@ -0,0 +1,156 @@
+// Package errorreport reports errors and panics to pluggable sinks, e.g. Github issues, SNS, a local file or a
+// webhook. Recurring errors are recognized by their fingerprint and rate limited, so that a bug hit by many users
+// doesn't flood the sinks.
+package errorreport
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/petergtz/alexa-journal/redact"
+	"github.com/petergtz/alexa-journal/tracing"
+	alexa "github.com/petergtz/go-alexa"
+	"go.uber.org/zap"
+)
+
+// Report is what sinks get to know about an error.
+type Report struct {
+	// ErrorID identifies this occurrence of the error in the logs.
+	ErrorID     int64  `json:"errorId"`
+	Fingerprint string `json:"fingerprint"`
+	CauseType   string `json:"causeType"`
+	// Error is the error message including its stack trace.
+	Error   string `json:"error"`
+	TraceID string `json:"traceId,omitempty"`
+	// Request is the redacted request the error occurred in, if any.
+	Request *alexa.RequestEnvelope `json:"request,omitempty"`
+	Time    time.Time              `json:"time"`
+	// Occurrences is how often the error occurred since it was last sent, including this time.
+	Occurrences int `json:"occurrences"`
+}
+
+// Sink sends reports somewhere people will see them.
+type Sink interface {
+	Send(ctx context.Context, report Report) error
+}
+
+const (
+	defaultSuppressRepeatsFor = 10 * time.Minute
+	defaultMaxReportsPerHour  = 20
+)
+
+// Reporter logs every error and sends it to its sinks, unless the same error was sent less than suppressRepeatsFor
+// ago or more than maxReportsPerHour reports were already sent in the current hour. Suppressed occurrences are
+// counted and sent along with the next report of the same error.
+type Reporter struct {
+	log      *zap.SugaredLogger
+	redactor redact.Redactor
+	sinks    []Sink
+
+	suppressRepeatsFor time.Duration
+	maxReportsPerHour  int
+	now                func() time.Time
+
+	mutex        sync.Mutex
+	lastSent     map[string]time.Time
+	suppressed   map[string]int
+	hourStart    time.Time
+	sentThisHour int
+}
+
+func NewReporter(log *zap.SugaredLogger, redactor redact.Redactor, sinks ...Sink) *Reporter {
+	return &Reporter{
+		log:                log,
+		redactor:           redactor,
+		sinks:              sinks,
+		suppressRepeatsFor: defaultSuppressRepeatsFor,
+		maxReportsPerHour:  defaultMaxReportsPerHour,
+		now:                time.Now,
+		lastSent:           make(map[string]time.Time),
+		suppressed:         make(map[string]int),
+	}
+}
+
+// WithRateLimit replaces the default rate limit of at most one report per error in 10 minutes and 20 reports
+// per hour.
+func (r *Reporter) WithRateLimit(suppressRepeatsFor time.Duration, maxReportsPerHour int) *Reporter {
+	r.suppressRepeatsFor = suppressRepeatsFor
+	r.maxReportsPerHour = maxReportsPerHour
+	return r
+}
+
+// WithClock makes the reporter use now instead of time.Now, e.g. to test rate limiting.
+func (r *Reporter) WithClock(now func() time.Time) *Reporter {
+	r.now = now
+	return r
+}
+
+func (r *Reporter) ReportPanic(ctx context.Context, e interface{}, requestEnv *alexa.RequestEnvelope) {
+	report := Report{
+		ErrorID:     rand.Int63(),
+		Fingerprint: Fingerprint(e),
+		CauseType:   causeTypeOf(e),
+		Error:       errorStringFrom(e),
+		TraceID:     tracing.TraceID(ctx),
+		Request:     r.redactor.RequestEnvelope(requestEnv),
+		Time:        r.now(),
+	}
+	log := r.log.With(
+		"error-id", report.ErrorID,
+		"fingerprint", report.Fingerprint,
+		"error", report.Error)
+	if report.TraceID != "" {
+		log = log.With("trace-id", report.TraceID)
+	}
+
+	occurrences, shouldSend := r.admit(report.Fingerprint, report.Time)
+	if !shouldSend {
+		log.Errorw("Internal Server Error", "reported", false)
+		return
+	}
+	report.Occurrences = occurrences
+	log.Errorw("Internal Server Error", "reported", true, "occurrences", occurrences)
+
+	for _, sink := range r.sinks {
+		if e := sink.Send(ctx, report); e != nil {
+			log.Errorw("Could not send error report", "sink", fmt.Sprintf("%T", sink), "sink-error", e)
+		}
+	}
+}
+
+func (r *Reporter) ReportError(ctx context.Context, e error) {
+	r.ReportPanic(ctx, e, nil)
+}
+
+// admit decides whether an error with the given fingerprint is sent now. If so, it also returns how often the
+// error occurred since it was last sent.
+func (r *Reporter) admit(fingerprint string, now time.Time) (occurrences int, shouldSend bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if now.Sub(r.hourStart) >= time.Hour {
+		r.hourStart = now
+		r.sentThisHour = 0
+	}
+	lastSent, sentBefore := r.lastSent[fingerprint]
+	if (sentBefore && now.Sub(lastSent) < r.suppressRepeatsFor) || r.sentThisHour >= r.maxReportsPerHour {
+		r.suppressed[fingerprint]++
+		return 0, false
+	}
+	occurrences = r.suppressed[fingerprint] + 1
+	delete(r.suppressed, fingerprint)
+	r.lastSent[fingerprint] = now
+	r.sentThisHour++
+	return occurrences, true
+}
+
+func errorStringFrom(e interface{}) string {
+	if _, hasStackTrace := e.(stackTracer); hasStackTrace {
+		return fmt.Sprintf("%+v", e)
+	}
+	return fmt.Sprintf("%v\n%s", e, debug.Stack())
+}
@@ -0,0 +1,182 @@
+package errorreport_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/petergtz/alexa-journal/errorreport"
+	"github.com/petergtz/alexa-journal/redact"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type recordingSink struct{ reports []errorreport.Report }
+
+func (s *recordingSink) Send(ctx context.Context, report errorreport.Report) error {
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+type failingSink struct{}
+
+func (failingSink) Send(ctx context.Context, report errorreport.Report) error {
+	return errors.New("sink down")
+}
+
+func someError() error      { return errors.New("some error") }
+func someOtherError() error { return errors.New("some error") }
+
+func panicAndReportWith(reporter *errorreport.Reporter, f func()) {
+	defer func() {
+		if e := recover(); e != nil {
+			reporter.ReportPanic(context.Background(), e, nil)
+		}
+	}()
+	f()
+}
+
+var _ = Describe("Reporter", func() {
+	var (
+		sink     *recordingSink
+		reporter *errorreport.Reporter
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		sink = &recordingSink{}
+		now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		reporter = errorreport.NewReporter(zap.NewNop().Sugar(), redact.Redactor{}, sink).
+			WithClock(func() time.Time { return now })
+	})
+
+	Describe("Fingerprint", func() {
+		It("is the same for errors from the same place", func() {
+			Expect(errorreport.Fingerprint(someError())).To(Equal(errorreport.Fingerprint(someError())))
+		})
+
+		It("differs for errors from different places", func() {
+			Expect(errorreport.Fingerprint(someError())).NotTo(Equal(errorreport.Fingerprint(someOtherError())))
+		})
+
+		It("differs for errors with different cause types from the same place", func() {
+			Expect(errorreport.Fingerprint(errors.Wrap(os.ErrNotExist, "x"))).NotTo(Equal(errorreport.Fingerprint(errors.Wrap(&os.PathError{}, "x"))))
+		})
+
+		It("identifies panics by where they happened", func() {
+			var slice []int
+			indexOutOfRange := func() { _ = slice[1] }
+			var m map[string]int
+			assignmentToNilMap := func() { m["key"] = 1 }
+
+			panicAndReportWith(reporter, indexOutOfRange)
+			now = now.Add(time.Hour)
+			panicAndReportWith(reporter, indexOutOfRange)
+			now = now.Add(time.Hour)
+			panicAndReportWith(reporter, assignmentToNilMap)
+
+			Expect(sink.reports).To(HaveLen(3))
+			Expect(sink.reports[0].Fingerprint).To(Equal(sink.reports[1].Fingerprint))
+			Expect(sink.reports[0].Fingerprint).NotTo(Equal(sink.reports[2].Fingerprint))
+		})
+	})
+
+	It("sends errors with their fingerprint, cause type and redacted request", func() {
+		requestEnv := &alexa.RequestEnvelope{
+			Request: &alexa.Request{Intent: alexa.Intent{Slots: map[string]alexa.IntentSlot{"text": {Value: "Dear diary"}}}},
+			Session: &alexa.Session{},
+		}
+
+		reporter.ReportPanic(context.Background(), someError(), requestEnv)
+
+		Expect(sink.reports).To(HaveLen(1))
+		Expect(sink.reports[0].Fingerprint).To(Equal(errorreport.Fingerprint(someError())))
+		Expect(sink.reports[0].CauseType).To(Equal("*errors.fundamental"))
+		Expect(sink.reports[0].Error).To(ContainSubstring("some error"))
+		Expect(sink.reports[0].Occurrences).To(Equal(1))
+		Expect(sink.reports[0].Request.Request.Intent.Slots["text"].Value).To(Equal(redact.Redacted))
+	})
+
+	It("sends a recurring error only once in a while, with the number of occurrences", func() {
+		for i := 0; i < 3; i++ {
+			reporter.ReportError(context.Background(), someError())
+		}
+		Expect(sink.reports).To(HaveLen(1))
+
+		now = now.Add(11 * time.Minute)
+		reporter.ReportError(context.Background(), someError())
+
+		Expect(sink.reports).To(HaveLen(2))
+		Expect(sink.reports[1].Occurrences).To(Equal(3))
+	})
+
+	It("sends at most the configured number of reports per hour", func() {
+		reporter.WithRateLimit(time.Minute, 1)
+
+		reporter.ReportError(context.Background(), someError())
+		reporter.ReportError(context.Background(), someOtherError())
+		Expect(sink.reports).To(HaveLen(1))
+
+		now = now.Add(time.Hour)
+		reporter.ReportError(context.Background(), someOtherError())
+		Expect(sink.reports).To(HaveLen(2))
+	})
+
+	It("still sends to the other sinks when one fails", func() {
+		reporter = errorreport.NewReporter(zap.NewNop().Sugar(), redact.Redactor{}, failingSink{}, sink)
+
+		reporter.ReportError(context.Background(), someError())
+
+		Expect(sink.reports).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Sinks", func() {
+	report := errorreport.Report{ErrorID: 123, Fingerprint: "abc", Error: "some error", Occurrences: 1}
+
+	It("appends reports to a file", func() {
+		dir, e := ioutil.TempDir("", "errorreport")
+		Expect(e).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		sink := &errorreport.FileSink{Path: filepath.Join(dir, "errors.jsonl")}
+
+		Expect(sink.Send(context.Background(), report)).To(Succeed())
+		Expect(sink.Send(context.Background(), report)).To(Succeed())
+
+		content, e := ioutil.ReadFile(sink.Path)
+		Expect(e).NotTo(HaveOccurred())
+		Expect(string(content)).To(MatchRegexp(`^\{.*"fingerprint":"abc".*\}\n\{.*\}\n$`))
+	})
+
+	It("posts reports to a webhook", func() {
+		var received errorreport.Report
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+		}))
+		defer server.Close()
+
+		Expect((&errorreport.WebhookSink{URL: server.URL, HTTPClient: server.Client()}).Send(context.Background(), report)).To(Succeed())
+
+		Expect(received.ErrorID).To(Equal(int64(123)))
+	})
+
+	It("fails when the webhook doesn't accept the report", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		Expect((&errorreport.WebhookSink{URL: server.URL, HTTPClient: server.Client()}).Send(context.Background(), report)).
+			To(MatchError(ContainSubstring("500")))
+	})
+})
@@ -0,0 +1,102 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/pkg/errors"
+)
+
+// SNSSink publishes reports to an SNS topic, e.g. to send them by email. LogsURL is a format string taking the
+// error ID, which turns it into a link to the logs.
+type SNSSink struct {
+	Client   *sns.SNS
+	TopicArn string
+	Subject  string
+	LogsURL  string
+}
+
+func (s *SNSSink) Send(ctx context.Context, report Report) error {
+	request, e := json.MarshalIndent(report.Request, "", "  ")
+	if e != nil {
+		return errors.Wrap(e, "Could not marshal request")
+	}
+	_, e = s.Client.PublishWithContext(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.TopicArn),
+		Subject:  aws.String(fmt.Sprintf("%v: Internal Server Error (ErrID: %v)", s.Subject, report.ErrorID)),
+		Message: aws.String(fmt.Sprintf(`ERROR DETAILS:
+error-id: %v
+fingerprint: %v
+occurrences: %v
+trace-id: %v
+error: %v
+
+ALEXA REQUEST:
+%s
+
+CLOUDWATCH QUERY:
+%v`, report.ErrorID, report.Fingerprint, report.Occurrences, report.TraceID, report.Error, request,
+			fmt.Sprintf(s.LogsURL, report.ErrorID))),
+	})
+	if e != nil {
+		return errors.Wrap(e, "Could not publish error report via SNS")
+	}
+	return nil
+}
+
+// FileSink appends reports as JSON lines to the file at Path.
+type FileSink struct {
+	Path string
+
+	mutex sync.Mutex
+}
+
+func (s *FileSink) Send(ctx context.Context, report Report) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	file, e := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if e != nil {
+		return errors.Wrapf(e, "Could not open error report file %v", s.Path)
+	}
+	defer file.Close()
+	if e := json.NewEncoder(file).Encode(report); e != nil {
+		return errors.Wrapf(e, "Could not write error report to %v", s.Path)
+	}
+	return nil
+}
+
+// WebhookSink posts reports as JSON to URL.
+type WebhookSink struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func (s *WebhookSink) Send(ctx context.Context, report Report) error {
+	body, e := json.Marshal(report)
+	if e != nil {
+		return errors.Wrap(e, "Could not marshal error report")
+	}
+	req, e := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(body))
+	if e != nil {
+		return errors.Wrap(e, "Could not create webhook request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, e := s.HTTPClient.Do(req)
+	if e != nil {
+		return errors.Wrap(e, "Could not post error report to webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("Webhook responded with status %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package export renders journal entries as documents users can read outside of the spreadsheet.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+	JSON     Format = "json"
+)
+
+var fileExtensions = map[Format]string{
+	Markdown: ".md",
+	HTML:     ".html",
+	JSON:     ".json",
+}
+
+// FormatFrom parses a format as given on the command line or as slot value ID, e.g. "html" or "HTML".
+func FormatFrom(s string) (Format, bool) {
+	format := Format(strings.ToLower(s))
+	_, ok := fileExtensions[format]
+	return format, ok
+}
+
+func (f Format) FileExtension() string {
+	return fileExtensions[f]
+}
+
+type Options struct {
+	Title string
+	// Language determines the month names in headings. Supported are "de" and "en". Defaults to "en".
+	Language string
+}
+
+// Export renders entries in the given format. Entries are sorted by date and, within the same date,
+// by the time they were written.
+func Export(entries []j.Entry, format Format, options Options) (string, error) {
+	entries = sorted(entries)
+	switch format {
+	case Markdown:
+		return markdownFrom(entries, options), nil
+	case HTML:
+		return htmlFrom(entries, options)
+	case JSON:
+		return jsonFrom(entries)
+	default:
+		return "", errors.Errorf("Unknown export format %v", format)
+	}
+}
+
+func sorted(entries []j.Entry) []j.Entry {
+	result := append([]j.Entry{}, entries...)
+	sort.SliceStable(result, func(a, b int) bool {
+		if result[a].EntryDate != result[b].EntryDate {
+			return result[a].EntryDate.Before(result[b].EntryDate)
+		}
+		return result[a].Timestamp.Before(result[b].Timestamp)
+	})
+	return result
+}
+
+type year struct {
+	Year   int
+	Months []month
+}
+
+type month struct {
+	Name    string
+	Entries []j.Entry
+}
+
+// groupedByYearAndMonth expects entries to be sorted.
+func groupedByYearAndMonth(entries []j.Entry, language string) []year {
+	var years []year
+	for _, entry := range entries {
+		if len(years) == 0 || years[len(years)-1].Year != entry.EntryDate.Year() {
+			years = append(years, year{Year: entry.EntryDate.Year()})
+		}
+		y := &years[len(years)-1]
+		monthName := monthNameIn(language, entry.EntryDate.Month())
+		if len(y.Months) == 0 || y.Months[len(y.Months)-1].Name != monthName {
+			y.Months = append(y.Months, month{Name: monthName})
+		}
+		m := &y.Months[len(y.Months)-1]
+		m.Entries = append(m.Entries, entry)
+	}
+	return years
+}
+
+var monthNames = map[string][]string{
+	"de": {"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+}
+
+func monthNameIn(language string, m time.Month) string {
+	if names, exists := monthNames[language]; exists {
+		return names[m-1]
+	}
+	return m.String()
+}
+
+func markdownFrom(entries []j.Entry, options Options) string {
+	var b strings.Builder
+	if options.Title != "" {
+		fmt.Fprintf(&b, "# %v\n\n", options.Title)
+	}
+	for _, y := range groupedByYearAndMonth(entries, options.Language) {
+		fmt.Fprintf(&b, "## %v\n\n", y.Year)
+		for _, m := range y.Months {
+			fmt.Fprintf(&b, "### %v\n\n", m.Name)
+			for _, entry := range m.Entries {
+				fmt.Fprintf(&b, "#### %v\n\n", entry.EntryDate)
+				if len(entry.Items) > 0 {
+					for i, item := range entry.Items {
+						fmt.Fprintf(&b, "%v. %v\n", i+1, item)
+					}
+					b.WriteString("\n")
+				} else {
+					fmt.Fprintf(&b, "%v\n\n", entry.EntryText)
+				}
+				if details := detailsOf(entry); details != "" {
+					fmt.Fprintf(&b, "*%v*\n\n", details)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+func detailsOf(entry j.Entry) string {
+	var details []string
+	if len(entry.Tags) > 0 {
+		details = append(details, "#"+strings.Join(entry.Tags, " #"))
+	}
+	if entry.Mood != 0 {
+		details = append(details, fmt.Sprintf("%v/%v", entry.Mood, j.MaxMood))
+	}
+	return strings.Join(details, " · ")
+}
+
+var htmlTemplate = template.Must(template.New("journal").Funcs(template.FuncMap{"details": detailsOf}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 40em; margin: 2em auto; line-height: 1.5; }
+h2 { page-break-before: always; }
+h2:first-of-type { page-break-before: avoid; }
+article { page-break-inside: avoid; margin-bottom: 1.5em; }
+.details { color: #666; font-style: italic; }
+@page { margin: 2cm; }
+</style>
+</head>
+<body>
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+{{- range .Years}}
+<h2>{{.Year}}</h2>
+{{- range .Months}}
+<h3>{{.Name}}</h3>
+{{- range .Entries}}
+<article>
+<h4>{{.EntryDate}}</h4>
+{{- if .Items}}
+<ol>
+{{- range .Items}}
+<li>{{.}}</li>
+{{- end}}
+</ol>
+{{- else}}
+<p>{{.EntryText}}</p>
+{{- end}}
+{{- with details .}}
+<p class="details">{{.}}</p>
+{{- end}}
+</article>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+func htmlFrom(entries []j.Entry, options Options) (string, error) {
+	var b bytes.Buffer
+	e := htmlTemplate.Execute(&b, map[string]interface{}{
+		"Title": options.Title,
+		"Years": groupedByYearAndMonth(entries, options.Language),
+	})
+	if e != nil {
+		return "", errors.Wrap(e, "Could not render HTML")
+	}
+	return b.String(), nil
+}
+
+type jsonEntry struct {
+	ID        string   `json:"id,omitempty"`
+	Date      string   `json:"date"`
+	Timestamp string   `json:"timestamp,omitempty"`
+	Text      string   `json:"text"`
+	Items     []string `json:"items,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Mood      int      `json:"mood,omitempty"`
+}
+
+func jsonFrom(entries []j.Entry) (string, error) {
+	jsonEntries := []jsonEntry{}
+	for _, entry := range entries {
+		timestamp := ""
+		if !entry.Timestamp.IsZero() {
+			timestamp = entry.Timestamp.Format(j.TimestampFormat)
+		}
+		jsonEntries = append(jsonEntries, jsonEntry{
+			ID:        entry.ID,
+			Date:      entry.EntryDate.String(),
+			Timestamp: timestamp,
+			Text:      entry.EntryText,
+			Items:     entry.Items,
+			Tags:      entry.Tags,
+			Mood:      entry.Mood,
+		})
+	}
+	b, e := json.MarshalIndent(jsonEntries, "", "  ")
+	if e != nil {
+		return "", errors.Wrap(e, "Could not marshal entries")
+	}
+	return string(b) + "\n", nil
+}
@@ -0,0 +1,122 @@
+package export_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/export"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/rickb777/date"
+)
+
+var _ = Describe("Export", func() {
+	var entries []j.Entry
+
+	BeforeEach(func() {
+		entries = []j.Entry{
+			{EntryDate: date.MustAutoParse("2019-03-02"), EntryText: "second", Timestamp: time.Date(2019, 3, 2, 20, 0, 0, 0, time.UTC), ID: "2", Mood: 7},
+			{EntryDate: date.MustAutoParse("2018-12-24"), EntryText: "first", ID: "1", Tags: []string{"family", "xmas"}},
+			{EntryDate: date.MustAutoParse("2019-03-02"), EntryText: "earlier that day", Timestamp: time.Date(2019, 3, 2, 8, 0, 0, 0, time.UTC), ID: "3"},
+			{EntryDate: date.MustAutoParse("2019-04-01"), EntryText: "sun. tea. friends", Items: []string{"sun", "tea", "friends"}, ID: "4"},
+		}
+	})
+
+	Describe("Markdown", func() {
+		It("groups entries by year and month", func() {
+			markdown, e := Export(entries, Markdown, Options{Title: "Journal"})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(markdown).To(Equal(`# Journal
+
+## 2018
+
+### December
+
+#### 2018-12-24
+
+first
+
+*#family #xmas*
+
+## 2019
+
+### March
+
+#### 2019-03-02
+
+earlier that day
+
+#### 2019-03-02
+
+second
+
+*7/10*
+
+### April
+
+#### 2019-04-01
+
+1. sun
+2. tea
+3. friends
+
+`))
+		})
+
+		It("uses localized month names", func() {
+			markdown, e := Export(entries, Markdown, Options{Language: "de"})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(markdown).To(ContainSubstring("### Dezember"))
+			Expect(markdown).To(ContainSubstring("### März"))
+		})
+	})
+
+	Describe("HTML", func() {
+		It("renders a standalone document with escaped entry texts", func() {
+			entries = append(entries, j.Entry{EntryDate: date.MustAutoParse("2019-04-02"), EntryText: "<script>"})
+
+			html, e := Export(entries, HTML, Options{Title: "Journal"})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(html).To(HavePrefix("<!DOCTYPE html>"))
+			Expect(html).To(ContainSubstring("<h1>Journal</h1>"))
+			Expect(html).To(ContainSubstring("<h2>2019</h2>\n<h3>March</h3>"))
+			Expect(html).To(ContainSubstring("<li>tea</li>"))
+			Expect(html).To(ContainSubstring("&lt;script&gt;"))
+		})
+	})
+
+	Describe("JSON", func() {
+		It("renders an array of entries", func() {
+			result, e := Export(entries, JSON, Options{})
+			Expect(e).NotTo(HaveOccurred())
+
+			var parsed []map[string]interface{}
+			Expect(json.Unmarshal([]byte(result), &parsed)).To(Succeed())
+			Expect(parsed).To(HaveLen(4))
+			Expect(parsed[0]).To(Equal(map[string]interface{}{
+				"id": "1", "date": "2018-12-24", "text": "first", "tags": []interface{}{"family", "xmas"},
+			}))
+			Expect(parsed[2]).To(Equal(map[string]interface{}{
+				"id": "2", "date": "2019-03-02", "timestamp": "2019-03-02 20:00:00", "text": "second", "mood": 7.0,
+			}))
+			Expect(parsed[3]["items"]).To(Equal([]interface{}{"sun", "tea", "friends"}))
+		})
+
+		It("renders an empty array for an empty journal", func() {
+			result, e := Export(nil, JSON, Options{})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(result).To(Equal("[]\n"))
+		})
+	})
+
+	It("parses formats", func() {
+		format, ok := FormatFrom("HTML")
+		Expect(ok).To(BeTrue())
+		Expect(format).To(Equal(HTML))
+		Expect(format.FileExtension()).To(Equal(".html"))
+
+		_, ok = FormatFrom("pdf")
+		Expect(ok).To(BeFalse())
+	})
+})
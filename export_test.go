@@ -0,0 +1,8 @@
+package journalskill
+
+// Exported for tests only.
+var (
+	RandomEntryNotIn    = randomEntryNotIn
+	RecentMemoryIDsWith = recentMemoryIDsWith
+	MaxRecentMemories   = maxRecentMemories
+)
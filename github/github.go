@@ -2,128 +2,103 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math/rand"
-	"runtime/debug"
-
-	"github.com/petergtz/go-alexa"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/sns"
-
-	"go.uber.org/zap"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/github"
+	"github.com/petergtz/alexa-journal/errorreport"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 )
 
-type GithubErrorReporter struct {
-	ghClient    *github.Client
-	logger      *zap.SugaredLogger
-	ctx         context.Context
-	owner       string
-	repo        string
-	logsURL     string
-	snsClient   *sns.SNS
-	snsTopicArn string
+// IssueSink reports errors as Github issues, one per fingerprint. When an open issue for the error's fingerprint
+// exists already, it comments on it instead of opening another one.
+//
+// Issues don't contain error messages or requests, because the repository may be public. They link to the logs
+// and the trace instead. logsURL and traceURL are format strings taking the error ID and the trace ID respectively.
+// traceURL may be empty, in which case issues mention the plain trace ID.
+type IssueSink struct {
+	ghClient *github.Client
+	owner    string
+	repo     string
+	logsURL  string
+	traceURL string
 }
 
-func NewGithubErrorReporter(owner, repo, token string, logger *zap.SugaredLogger, logsURL string, snsClient *sns.SNS, snsTopicArn string) *GithubErrorReporter {
-	ctx := context.TODO()
-	return &GithubErrorReporter{
-		ghClient:    github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))),
-		ctx:         ctx,
-		logger:      logger,
-		repo:        repo,
-		owner:       owner,
-		logsURL:     logsURL,
-		snsClient:   snsClient,
-		snsTopicArn: snsTopicArn,
+func NewIssueSink(owner, repo, token string, logsURL string, traceURL string) *IssueSink {
+	return &IssueSink{
+		ghClient: github.NewClient(oauth2.NewClient(context.TODO(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))),
+		owner:    owner,
+		repo:     repo,
+		logsURL:  logsURL,
+		traceURL: traceURL,
 	}
 }
 
-func (r *GithubErrorReporter) ReportPanic(e interface{}, requestEnv *alexa.RequestEnvelope) {
-	errorID := rand.Int63()
-	errorString := errorStringFrom(e)
-
-	issue, _, ghErr := r.ghClient.Issues.Create(r.ctx, r.owner, r.repo, &github.IssueRequest{
-		Title: github.String(fmt.Sprintf("Internal Server Error (ErrID: %v)", errorID)),
-		Body:  github.String(fmt.Sprintf("An error occurred and it can be found using %v", fmt.Sprintf(r.logsURL, errorID))),
-	})
-
-	attributes := map[string]interface{}{
-		"error-id": errorID,
-		"error":    errorString,
+// WithBaseURL makes the sink talk to another Github API endpoint than api.github.com, e.g. Github Enterprise.
+func (s *IssueSink) WithBaseURL(baseURL string) *IssueSink {
+	u, e := url.Parse(strings.TrimSuffix(baseURL, "/") + "/")
+	if e != nil {
+		panic(errors.Wrapf(e, "Invalid Github base URL %v", baseURL))
 	}
-	if ghErr != nil {
-		attributes["github-error"] = ghErr
-
-		r.logger.Errorw("Error while trying to report Internal Server Error", slicify(attributes)...)
-	} else {
-		attributes["github-issue-id"] = *issue.Number
-		attributes["github-issue-url"] = issue.GetHTMLURL()
+	s.ghClient.BaseURL = u
+	return s
+}
 
-		r.logger.Errorw("Internal Server Error", slicify(attributes)...)
+func (s *IssueSink) Send(ctx context.Context, report errorreport.Report) error {
+	issueNumber, e := s.openIssueFor(ctx, report.Fingerprint)
+	if e != nil {
+		return e
 	}
-
-	_, snsErr := r.snsClient.Publish(&sns.PublishInput{
-		TopicArn: aws.String(r.snsTopicArn),
-		Subject:  aws.String(fmt.Sprintf(r.repo+": Internal Server Error (ErrID: %v)", errorID)),
-		Message: aws.String(fmt.Sprintf(`ERROR DETAILS:
-%s
-
-ALEXA REQUEST:
-%v
-
-CLOUDWATCH QUERY:
-%v`, stringify(attributes), alexaRequestString(requestEnv), fmt.Sprintf(r.logsURL, errorID))),
-	})
-
-	if snsErr != nil {
-		attributes["sns-error"] = snsErr
-
-		r.logger.Errorw("Error while trying to publish Internal Server Error via SNS", slicify(attributes)...)
+	if issueNumber != 0 {
+		_, _, e = s.ghClient.Issues.CreateComment(ctx, s.owner, s.repo, issueNumber, &github.IssueComment{
+			Body: github.String(s.commentBody(report)),
+		})
+		return errors.Wrapf(e, "Could not comment on issue %v", issueNumber)
 	}
+	_, _, e = s.ghClient.Issues.Create(ctx, s.owner, s.repo, &github.IssueRequest{
+		Title: github.String(issueTitle(report.Fingerprint)),
+		Body:  github.String(s.issueBody(report)),
+	})
+	return errors.Wrap(e, "Could not create issue")
 }
 
-func (r *GithubErrorReporter) ReportError(e error) {
-	r.ReportPanic(e, nil)
+func issueTitle(fingerprint string) string {
+	return fmt.Sprintf("Internal Server Error (Fingerprint: %v)", fingerprint)
 }
 
-func errorStringFrom(e interface{}) string {
-	if _, hasStackTrace := e.(interface{ StackTrace() errors.StackTrace }); hasStackTrace {
-		return fmt.Sprintf("%+v", e)
+// openIssueFor returns the number of the open issue for fingerprint, or 0 if there is none.
+func (s *IssueSink) openIssueFor(ctx context.Context, fingerprint string) (int, error) {
+	result, _, e := s.ghClient.Search.Issues(ctx,
+		fmt.Sprintf(`repo:%v/%v is:issue is:open in:title "%v"`, s.owner, s.repo, issueTitle(fingerprint)),
+		&github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if e != nil {
+		return 0, errors.Wrap(e, "Could not search for existing issue")
 	}
-	return fmt.Sprintf("%v\n%s", e, debug.Stack())
+	if len(result.Issues) == 0 {
+		return 0, nil
+	}
+	return result.Issues[0].GetNumber(), nil
 }
 
-func stringify(m map[string]interface{}) string {
-	result := ""
-	for k, v := range m {
-		result += fmt.Sprintf("%v: %v\n", k, v)
-	}
-	return result
+func (s *IssueSink) issueBody(report errorreport.Report) string {
+	return fmt.Sprintf("An error occurred and it can be found using %v\n\nCause type: `%v`\nFingerprint: %v%v",
+		fmt.Sprintf(s.logsURL, report.ErrorID), report.CauseType, report.Fingerprint, s.traceReference(report.TraceID))
 }
 
-func slicify(m map[string]interface{}) []interface{} {
-	result := make([]interface{}, 0)
-	for k, v := range m {
-		result = append(result, k, v)
-	}
-	return result
+func (s *IssueSink) commentBody(report errorreport.Report) string {
+	return fmt.Sprintf("Occurred again (%v times since last reported). It can be found using %v%v",
+		report.Occurrences, fmt.Sprintf(s.logsURL, report.ErrorID), s.traceReference(report.TraceID))
 }
 
-func alexaRequestString(requestEnv *alexa.RequestEnvelope) string {
-	if requestEnv == nil {
-		return "Not available."
-	}
-	r := *requestEnv
-	r.Session.User.AccessToken = "<REDACTED>"
-	buf, e := json.MarshalIndent(r, "", "  ")
-	if e != nil {
-		return "Error while marshalling request. Error: " + e.Error()
+func (s *IssueSink) traceReference(traceID string) string {
+	switch {
+	case traceID == "":
+		return ""
+	case s.traceURL == "":
+		return fmt.Sprintf("\n\nTrace ID: %v", traceID)
+	default:
+		return fmt.Sprintf("\n\nTrace: %v", fmt.Sprintf(s.traceURL, traceID))
 	}
-	return string(buf)
 }
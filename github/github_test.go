@@ -1,6 +1,7 @@
 package github_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"strings"
@@ -10,7 +11,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/sns"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/petergtz/alexa-journal/errorreport"
 	. "github.com/petergtz/alexa-journal/github"
+	"github.com/petergtz/alexa-journal/redact"
 	"go.uber.org/zap"
 )
 
@@ -25,17 +28,15 @@ var _ = XDescribe("Github", func() {
 		defer l.Sync()
 		log := l.Sugar()
 
-		er := NewGithubErrorReporter(
+		er := errorreport.NewReporter(log, redact.Redactor{}, NewIssueSink(
 			"petergtz",
 			"alexa-journal",
 			strings.TrimSpace(string(token)),
-			log,
 			"logsUrl %v",
-			sns.New(session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-west-1")}))),
-			"arn:aws:sns:eu-west-1:512841817041:AlexaJournalErrors",
-		)
+			"",
+		))
 
-		er.ReportPanic("Testing: Some error occurred", nil)
+		er.ReportPanic(context.Background(), "Testing: Some error occurred", nil)
 	})
 
 	It("can publish on SNS topic", func() {
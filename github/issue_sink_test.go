@@ -0,0 +1,87 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/petergtz/alexa-journal/errorreport"
+	. "github.com/petergtz/alexa-journal/github"
+)
+
+var _ = Describe("IssueSink", func() {
+	var (
+		server        *httptest.Server
+		openIssues    []map[string]interface{}
+		searchQueries []string
+		createdIssues []map[string]interface{}
+		comments      map[string][]map[string]interface{}
+		sink          *IssueSink
+		report        errorreport.Report
+	)
+
+	BeforeEach(func() {
+		openIssues = nil
+		searchQueries = nil
+		createdIssues = nil
+		comments = make(map[string][]map[string]interface{})
+		mux := http.NewServeMux()
+		mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+			searchQueries = append(searchQueries, r.URL.Query().Get("q"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"total_count": len(openIssues), "items": openIssues})
+		})
+		mux.HandleFunc("/repos/petergtz/alexa-journal/issues", func(w http.ResponseWriter, r *http.Request) {
+			var issue map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&issue)
+			createdIssues = append(createdIssues, issue)
+			json.NewEncoder(w).Encode(map[string]interface{}{"number": 42})
+		})
+		mux.HandleFunc("/repos/petergtz/alexa-journal/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+			var comment map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&comment)
+			comments["7"] = append(comments["7"], comment)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1})
+		})
+		server = httptest.NewServer(mux)
+
+		sink = NewIssueSink("petergtz", "alexa-journal", "some-token", "logs query for %v", "https://traces.example.com/%v").
+			WithBaseURL(server.URL)
+		report = errorreport.Report{
+			ErrorID:     123,
+			Fingerprint: "abc123",
+			CauseType:   "*errors.fundamental",
+			Error:       "some error with personal data",
+			TraceID:     "some-trace-id",
+			Occurrences: 3,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("opens an issue linking to logs and trace, but without the error message", func() {
+		Expect(sink.Send(context.Background(), report)).To(Succeed())
+
+		Expect(searchQueries).To(ConsistOf(ContainSubstring(`"Internal Server Error (Fingerprint: abc123)"`)))
+		Expect(createdIssues).To(HaveLen(1))
+		Expect(createdIssues[0]["title"]).To(Equal("Internal Server Error (Fingerprint: abc123)"))
+		Expect(createdIssues[0]["body"]).To(ContainSubstring("logs query for 123"))
+		Expect(createdIssues[0]["body"]).To(ContainSubstring("https://traces.example.com/some-trace-id"))
+		Expect(createdIssues[0]["body"]).NotTo(ContainSubstring("personal data"))
+	})
+
+	It("comments on the open issue with the same fingerprint instead of opening another one", func() {
+		openIssues = []map[string]interface{}{{"number": 7, "title": "Internal Server Error (Fingerprint: abc123)"}}
+
+		Expect(sink.Send(context.Background(), report)).To(Succeed())
+
+		Expect(createdIssues).To(BeEmpty())
+		Expect(comments["7"]).To(HaveLen(1))
+		Expect(comments["7"][0]["body"]).To(ContainSubstring("3 times"))
+		Expect(comments["7"][0]["body"]).To(ContainSubstring("logs query for 123"))
+	})
+})
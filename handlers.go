@@ -0,0 +1,153 @@
+package journalskill
+
+import (
+	"context"
+	"time"
+
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/locale"
+	alexa "github.com/petergtz/go-alexa"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Input is everything a RequestHandler needs to know about a request. The request interceptors fill it in
+// before a handler is chosen.
+type Input struct {
+	Ctx            context.Context
+	Start          time.Time
+	RequestEnv     *alexa.RequestEnvelope
+	RequestContext *RequestContext
+	Log            *zap.SugaredLogger
+	Config         Config
+	Localizer      *locale.Localizer
+	// Journal is only loaded for intent requests.
+	Journal j.Journal
+	Session SessionAttributes
+	// Mutations are the changes to the journal made while handling the request.
+	Mutations []Mutation
+
+	deferred []func()
+	span     trace.Span
+	// succeeded tells LatencyMetrics that the request didn't panic.
+	succeeded bool
+}
+
+type MutationKind string
+
+const (
+	EntryAdded   MutationKind = "add"
+	EntryDeleted MutationKind = "delete"
+	EntryEdited  MutationKind = "edit"
+)
+
+type Mutation struct {
+	Kind    MutationKind
+	EntryID string
+	Time    time.Time
+}
+
+// RequestHandler handles one kind of request, usually one intent. The JournalSkill asks its handlers in order
+// and the first one that can handle the request gets it.
+type RequestHandler interface {
+	CanHandle(in *Input) bool
+	Handle(in *Input) *alexa.ResponseEnvelope
+}
+
+// RequestInterceptor prepares the Input before the request is handed to a RequestHandler. If it returns a response,
+// the request is answered with it right away and no handler is asked.
+type RequestInterceptor interface {
+	Intercept(in *Input) *alexa.ResponseEnvelope
+}
+
+type RequestInterceptorFunc func(in *Input) *alexa.ResponseEnvelope
+
+func (f RequestInterceptorFunc) Intercept(in *Input) *alexa.ResponseEnvelope { return f(in) }
+
+// ResponseInterceptor sees the response before it's returned to Alexa. It's not called when handling the
+// request panicked.
+type ResponseInterceptor interface {
+	InterceptResponse(in *Input, responseEnv *alexa.ResponseEnvelope)
+}
+
+type ResponseInterceptorFunc func(in *Input, responseEnv *alexa.ResponseEnvelope)
+
+func (f ResponseInterceptorFunc) InterceptResponse(in *Input, responseEnv *alexa.ResponseEnvelope) {
+	f(in, responseEnv)
+}
+
+type intentHandler struct {
+	intentNames []string
+	handle      func(in *Input) *alexa.ResponseEnvelope
+}
+
+// forIntents returns a RequestHandler that handles intent requests for the given intents with handle.
+func forIntents(handle func(in *Input) *alexa.ResponseEnvelope, intentNames ...string) RequestHandler {
+	return &intentHandler{intentNames: intentNames, handle: handle}
+}
+
+func (h *intentHandler) CanHandle(in *Input) bool {
+	if in.RequestEnv.Request.Type != "IntentRequest" {
+		return false
+	}
+	for _, intentName := range h.intentNames {
+		if in.RequestEnv.Request.Intent.Name == intentName {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *intentHandler) Handle(in *Input) *alexa.ResponseEnvelope { return h.handle(in) }
+
+type requestTypeHandler struct {
+	requestType string
+	handle      func(in *Input) *alexa.ResponseEnvelope
+}
+
+// forRequestType returns a RequestHandler that handles all requests of the given type with handle.
+func forRequestType(requestType string, handle func(in *Input) *alexa.ResponseEnvelope) RequestHandler {
+	return &requestTypeHandler{requestType: requestType, handle: handle}
+}
+
+func (h *requestTypeHandler) CanHandle(in *Input) bool {
+	return in.RequestEnv.Request.Type == h.requestType
+}
+
+func (h *requestTypeHandler) Handle(in *Input) *alexa.ResponseEnvelope { return h.handle(in) }
+
+func (in *Input) userID() string      { return in.RequestEnv.Session.User.UserID }
+func (in *Input) accessToken() string { return in.RequestEnv.Session.User.AccessToken }
+
+// Response starts a response that leaves the session attributes as they came with the request.
+func (in *Input) Response() *ResponseBuilder {
+	return NewResponse().SessionAttributes(in.RequestEnv.Session.Attributes)
+}
+
+// ResponseWithSession starts a response that carries the possibly modified in.Session.
+func (in *Input) ResponseWithSession() *ResponseBuilder {
+	return NewResponse().SessionAttributes(mapStringInterfaceFrom(in.Session))
+}
+
+// intentName returns the name of the requested intent or, if it's not an intent request, the request type.
+func (in *Input) intentName() string {
+	if in.RequestEnv.Request.Type == "IntentRequest" {
+		return in.RequestEnv.Request.Intent.Name
+	}
+	return in.RequestEnv.Request.Type
+}
+
+func (in *Input) recordMutation(kind MutationKind, entryID string) {
+	in.Mutations = append(in.Mutations, Mutation{Kind: kind, EntryID: entryID, Time: time.Now()})
+}
+
+// Defer registers f to be called once the response is ready, like a defer statement in ProcessRequest would.
+func (in *Input) Defer(f func()) {
+	in.deferred = append(in.deferred, f)
+}
+
+func (in *Input) runDeferred() {
+	for i := len(in.deferred) - 1; i >= 0; i-- {
+		in.deferred[i]()
+	}
+}
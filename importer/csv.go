@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
+
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+// CSVColumns describes which columns of a CSV file contain what. Column numbers start at 0.
+type CSVColumns struct {
+	Date int
+	Text int
+	// Tags is the column of comma-separated tags, or -1 if there is none.
+	Tags int
+	// DateFormat is the date layout as understood by time.Parse. If empty, ISO dates as well as
+	// dd.mm.yyyy and yyyy/mm/dd are accepted.
+	DateFormat string
+	Comma      rune
+	// SkipHeader skips the first row.
+	SkipHeader bool
+}
+
+// CSV reads entries from a generic CSV export.
+func CSV(r io.Reader, columns CSVColumns) ([]j.Entry, error) {
+	reader := csv.NewReader(r)
+	if columns.Comma != 0 {
+		reader.Comma = columns.Comma
+	}
+	reader.FieldsPerRecord = -1
+	records, e := reader.ReadAll()
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not parse CSV")
+	}
+	if columns.SkipHeader && len(records) > 0 {
+		records = records[1:]
+	}
+	var entries []j.Entry
+	for i, record := range records {
+		if len(record) <= columns.Date || len(record) <= columns.Text {
+			return nil, errors.Errorf("Row %v has only %v columns", i+1, len(record))
+		}
+		entryDate, e := dateFrom(strings.TrimSpace(record[columns.Date]), columns.DateFormat)
+		if e != nil {
+			return nil, errors.Wrapf(e, "Could not parse date in row %v", i+1)
+		}
+		entry := j.Entry{EntryDate: entryDate, EntryText: record[columns.Text]}
+		if columns.Tags >= 0 && columns.Tags < len(record) {
+			for _, tag := range strings.Split(record[columns.Tags], ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					entry.Tags = append(entry.Tags, tag)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func dateFrom(s string, format string) (date.Date, error) {
+	if format != "" {
+		return date.Parse(format, s)
+	}
+	return date.AutoParse(s)
+}
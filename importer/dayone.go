@@ -0,0 +1,80 @@
+// Package importer reads entries from other journaling apps' exports, so they can be imported into a journal.
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
+
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+type dayOneExport struct {
+	Entries []struct {
+		UUID         string    `json:"uuid"`
+		CreationDate time.Time `json:"creationDate"`
+		TimeZone     string    `json:"timeZone"`
+		Text         string    `json:"text"`
+		Tags         []string  `json:"tags"`
+	} `json:"entries"`
+}
+
+// DayOne reads a Day One JSON export, i.e. the JSON file inside the ZIP file Day One exports.
+// Entry dates are taken in the time zone the entry was written in.
+func DayOne(r io.Reader) ([]j.Entry, error) {
+	var export dayOneExport
+	e := json.NewDecoder(r).Decode(&export)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not parse Day One export")
+	}
+	var entries []j.Entry
+	for _, entry := range export.Entries {
+		timestamp := inTimeZone(entry.CreationDate, entry.TimeZone)
+		entries = append(entries, j.Entry{
+			Timestamp: timestamp,
+			EntryDate: date.NewAt(timestamp),
+			EntryText: entry.Text,
+			Tags:      entry.Tags,
+		})
+	}
+	return entries, nil
+}
+
+type journeyEntry struct {
+	ID          string   `json:"id"`
+	Text        string   `json:"text"`
+	DateJournal int64    `json:"date_journal"`
+	TimeZone    string   `json:"timezone"`
+	Tags        []string `json:"tags"`
+}
+
+// Journey reads a single entry of a Journey export. Journey exports one JSON file per entry.
+func Journey(r io.Reader) (j.Entry, error) {
+	var entry journeyEntry
+	e := json.NewDecoder(r).Decode(&entry)
+	if e != nil {
+		return j.Entry{}, errors.Wrap(e, "Could not parse Journey entry")
+	}
+	if entry.DateJournal == 0 {
+		return j.Entry{}, errors.Errorf("Journey entry %v has no date", entry.ID)
+	}
+	timestamp := inTimeZone(time.Unix(0, entry.DateJournal*int64(time.Millisecond)), entry.TimeZone)
+	return j.Entry{
+		Timestamp: timestamp,
+		EntryDate: date.NewAt(timestamp),
+		EntryText: entry.Text,
+		Tags:      entry.Tags,
+	}, nil
+}
+
+// inTimeZone converts t into the given IANA time zone, or to UTC if the time zone is unknown.
+func inTimeZone(t time.Time, timeZone string) time.Time {
+	location, e := time.LoadLocation(timeZone)
+	if timeZone == "" || e != nil {
+		location = time.UTC
+	}
+	return t.In(location)
+}
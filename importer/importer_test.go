@@ -0,0 +1,127 @@
+package importer_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/importer"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+	"github.com/rickb777/date"
+)
+
+var _ = Describe("Importer", func() {
+	Describe("DayOne", func() {
+		It("reads entries in the time zone they were written in", func() {
+			entries, e := DayOne(strings.NewReader(`{
+				"metadata": {"version": "1.0"},
+				"entries": [
+					{"uuid": "A1", "creationDate": "2018-12-31T23:30:00Z", "timeZone": "Europe/Berlin", "text": "Happy new year", "tags": ["party"]},
+					{"uuid": "A2", "creationDate": "2019-01-02T08:00:00Z", "timeZone": "", "text": "Back to work"}
+				]
+			}`))
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("2019-01-01")))
+			Expect(entries[0].EntryText).To(Equal("Happy new year"))
+			Expect(entries[0].Tags).To(Equal([]string{"party"}))
+			Expect(entries[1].EntryDate).To(Equal(date.MustAutoParse("2019-01-02")))
+		})
+
+		It("returns an error for invalid JSON", func() {
+			_, e := DayOne(strings.NewReader(`{"entries": [`))
+			Expect(e).To(HaveOccurred())
+		})
+	})
+
+	Describe("Journey", func() {
+		It("reads an entry", func() {
+			entry, e := Journey(strings.NewReader(`{"id": "1546300800000-abc", "text": "Fireworks", "date_journal": 1546297200000, "timezone": "Europe/Berlin", "tags": ["party"]}`))
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.EntryDate).To(Equal(date.MustAutoParse("2019-01-01")))
+			Expect(entry.EntryText).To(Equal("Fireworks"))
+			Expect(entry.Tags).To(Equal([]string{"party"}))
+		})
+
+		It("returns an error for entries without date", func() {
+			_, e := Journey(strings.NewReader(`{"id": "x", "text": "Fireworks"}`))
+			Expect(e).To(HaveOccurred())
+		})
+	})
+
+	Describe("CSV", func() {
+		It("reads the configured columns", func() {
+			entries, e := CSV(strings.NewReader("text;day;tags\n\"Hello; world\";20.08.1994;\"a, b\"\nSecond;21.08.1994;\n"),
+				CSVColumns{Date: 1, Text: 0, Tags: 2, Comma: ';', SkipHeader: true})
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("1994-08-20")))
+			Expect(entries[0].EntryText).To(Equal("Hello; world"))
+			Expect(entries[0].Tags).To(Equal([]string{"a", "b"}))
+			Expect(entries[1].Tags).To(BeEmpty())
+		})
+
+		It("uses the configured date format", func() {
+			entries, e := CSV(strings.NewReader("08/20/1994,Hello\n"), CSVColumns{Date: 0, Text: 1, Tags: -1, DateFormat: "01/02/2006"})
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("1994-08-20")))
+		})
+
+		It("returns an error for unparsable dates", func() {
+			_, e := CSV(strings.NewReader("yesterday,Hello\n"), CSVColumns{Date: 0, Text: 1, Tags: -1})
+			Expect(e).To(MatchError(ContainSubstring("row 1")))
+		})
+	})
+
+	Describe("TSV", func() {
+		It("reads a journal in the skill's format without changing the file", func() {
+			dir, e := ioutil.TempDir("", "importer")
+			Expect(e).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "journal.tsv")
+			content := "timestamp\tdate\ttext\n2019-01-01 10:00:00\t2019-01-01\tHello\n"
+			Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+
+			entries, e := TSV(path)
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].EntryText).To(Equal("Hello"))
+			Expect(ioutil.ReadFile(path)).To(Equal([]byte(content)))
+		})
+
+		It("keeps imported entries with line breaks and tabs in one row each", func() {
+			dir, e := ioutil.TempDir("", "importer")
+			Expect(e).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "journal.tsv")
+			dayOneEntries, e := DayOne(strings.NewReader(`{"entries": [
+				{"uuid": "A1", "creationDate": "2019-01-01T10:00:00Z", "text": "First paragraph\n\nSecond paragraph\r\nLast line"}
+			]}`))
+			Expect(e).NotTo(HaveOccurred())
+			csvEntries, e := CSV(strings.NewReader("02.01.2019;\"Before\tafter\"\n"), CSVColumns{Date: 0, Text: 1, Comma: ';'})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(path, nil, 0644)).To(Succeed())
+			journal := j.Journal{Data: &tsv.TextFileBackedTabularData{TextFileLoader: &tsv.LocalFile{Path: path}}}
+
+			_, e = journal.ImportEntries(context.Background(), append(dayOneEntries, csvEntries...), 100)
+			Expect(e).NotTo(HaveOccurred())
+
+			entries, e := TSV(path)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].EntryText).To(Equal("First paragraph  Second paragraph Last line"))
+			Expect(entries[1].EntryText).To(Equal("Before after"))
+			Expect(journal.ParseReport().Err()).NotTo(HaveOccurred())
+		})
+	})
+})
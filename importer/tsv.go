@@ -0,0 +1,21 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+)
+
+// TSV reads all entries of a journal in the skill's own TSV format, e.g. a journal downloaded from Google Sheets.
+// The file is not changed.
+func TSV(path string) ([]j.Entry, error) {
+	journal := j.Journal{Data: &tsv.TextFileBackedTabularData{TextFileLoader: &tsv.LocalFile{Path: path, ReadOnly: true}}}
+	entries, e := journal.GetEntries(context.Background(), "")
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not read journal %v", path)
+	}
+	return entries, nil
+}
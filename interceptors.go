@@ -0,0 +1,106 @@
+package journalskill
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/petergtz/alexa-journal/locale"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	"github.com/petergtz/alexa-journal/util"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+)
+
+// requireAccessToken asks the user to link their Google account, as long as they haven't.
+func (h *JournalSkill) requireAccessToken(in *Input) *alexa.ResponseEnvelope {
+	if in.accessToken() != "" {
+		return nil
+	}
+	return in.Response().
+		Speak(i18n.NewLocalizer(h.i18nBundle, in.RequestEnv.Request.Locale).
+			MustLocalize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: r.LinkWithGoogleAccount.String()}})).
+		LinkAccountCard().
+		EndSession().
+		Build()
+}
+
+func (h *JournalSkill) loadConfig(in *Input) *alexa.ResponseEnvelope {
+	in.Config = h.configService.GetConfig(in.Ctx, in.userID())
+	in.Localizer = locale.NewLocalizer(h.i18nBundle, in.RequestEnv.Request.Locale, in.Config.BeSuccinct)
+	return nil
+}
+
+// speakWhileSlowForIntents sends a progressive response when handling an intent takes long.
+func (h *JournalSkill) speakWhileSlowForIntents(in *Input) *alexa.ResponseEnvelope {
+	if in.RequestEnv.Request.Type == "IntentRequest" {
+		in.Defer(h.speakWhileSlow(in))
+	}
+	return nil
+}
+
+// journalIndependentIntents are handled without loading the active journal, so that users can still manage their
+// journals when the active one can't be loaded.
+var journalIndependentIntents = map[string]bool{
+	"ListJournalsIntent":      true,
+	"CreateJournalIntent":     true,
+	"SwitchJournalIntent":     true,
+	"ChooseJournalFileIntent": true,
+	"UseJournalFolderIntent":  true,
+	"AMAZON.YesIntent":        true,
+	"AMAZON.NoIntent":         true,
+}
+
+// loadJournal makes the journal available to intent handlers.
+func (h *JournalSkill) loadJournal(in *Input) *alexa.ResponseEnvelope {
+	if in.RequestEnv.Request.Type != "IntentRequest" || journalIndependentIntents[in.intentName()] {
+		return nil
+	}
+	location := h.journalLocationFor(in.Config, in.Localizer)
+	journal, e := h.journalProvider.Get(in.Ctx, in.accessToken(), location)
+	if e != nil && location.SpreadsheetID != "" && h.errorInterpreter.IsNotFound(e) {
+		// The remembered spreadsheet was deleted or isn't shared anymore. Forgetting it lets the user continue with
+		// the journal found by its name, instead of failing on every request.
+		in.Log.Warnw("Remembered spreadsheet not found. Looking up journal by name instead.", "error", e)
+		in.Config = withSpreadsheetID(in.Config, "")
+		h.configService.PersistConfig(in.Ctx, in.userID(), in.Config)
+		location.SpreadsheetID = ""
+		journal, e = h.journalProvider.Get(in.Ctx, in.accessToken(), location)
+	}
+	if e != nil {
+		in.Log.Errorw("Error while getting journal via journalProvider", "error", e)
+		return h.journalErrorResponse(in, "", e)
+	}
+	in.Log.Debugw("Journal downloaded")
+	in.Journal = journal
+	if location.SpreadsheetID == "" && journal.StorageID != "" {
+		// Remembering the spreadsheet keeps the journal when the user switches languages.
+		in.Config = withSpreadsheetID(in.Config, journal.StorageID)
+		h.configService.PersistConfig(in.Ctx, in.userID(), in.Config)
+	}
+	return nil
+}
+
+// routeBareNumbers hands a number the user says on its own to the question it answers. Such numbers are recognized
+// as RateMoodIntent, but they can also answer which spreadsheet to use for the journal.
+func routeBareNumbers(in *Input) *alexa.ResponseEnvelope {
+	if in.intentName() != "RateMoodIntent" || in.Session.EntryIDAwaitingMood != "" ||
+		len(in.Session.JournalFileCandidates) == 0 {
+		return nil
+	}
+	requestEnv := *in.RequestEnv
+	request := *requestEnv.Request
+	request.Intent = alexa.Intent{
+		Name:  "ChooseJournalFileIntent",
+		Slots: map[string]alexa.IntentSlot{"number": {Name: "number", Value: request.Intent.Slots["rating"].Value}},
+	}
+	requestEnv.Request = &request
+	in.RequestEnv = &requestEnv
+	return nil
+}
+
+func decodeSession(in *Input) *alexa.ResponseEnvelope {
+	in.Session.Drafts = make(map[string][]string)
+	in.Session.Prompts = make(map[string]string)
+	e := mapstructure.Decode(in.RequestEnv.Session.Attributes, &in.Session)
+	util.PanicOnError(errors.Wrap(e, "Could not parse sessionAttributes"))
+	return nil
+}
@@ -0,0 +1,216 @@
+package journalskill_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal"
+	"github.com/petergtz/alexa-journal/cmd/skill/factory"
+	"github.com/petergtz/alexa-journal/drive"
+	"github.com/petergtz/alexa-journal/journal"
+	. "github.com/petergtz/alexa-journal/matchers"
+	"github.com/petergtz/alexa-journal/metrics"
+	"github.com/petergtz/alexa-journal/redact"
+	"github.com/petergtz/alexa-journal/tracing"
+	"github.com/petergtz/alexa-journal/tsv"
+	"github.com/petergtz/go-alexa"
+	"github.com/petergtz/pegomock"
+	. "github.com/petergtz/pegomock/ginkgo_compatible"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("Interceptors", func() {
+	var (
+		skill           *JournalSkill
+		journalProvider *MockJournalProvider
+		logger          *zap.SugaredLogger
+		logs            *observer.ObservedLogs
+		errorReporter   *MockErrorReporter
+	)
+
+	BeforeEach(func() {
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		logger = zap.New(core).Sugar()
+		journalProvider = NewMockJournalProvider()
+		Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+			ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}}, nil)
+		errorReporter = NewMockErrorReporter()
+		skill = NewJournalSkill(journalProvider,
+			&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+			logger,
+			errorReporter,
+			factory.CreateI18nBundle(),
+			factory.NewMemoryConfigService(),
+			nil,
+			nil,
+			nil)
+	})
+
+	newEntryRequest := func(confirmationStatus string) *alexa.RequestEnvelope {
+		return &alexa.RequestEnvelope{
+			Request: &alexa.Request{
+				Locale:      "en_US",
+				Type:        "IntentRequest",
+				DialogState: "IN_PROGRESS",
+				Intent: alexa.Intent{
+					Name:               "NewEntryIntent",
+					ConfirmationStatus: confirmationStatus,
+					Slots: map[string]alexa.IntentSlot{
+						"date": {Name: "date", Value: "2026-10-19"},
+						"text": {Name: "text", Value: "my secret thoughts"},
+					},
+				},
+			},
+			Session: &alexa.Session{
+				User: struct {
+					UserID      string "json:\"userId\""
+					AccessToken string "json:\"accessToken\""
+				}{UserID: "some-user", AccessToken: "some-token"},
+				Attributes: map[string]interface{}{
+					"drafting": true,
+					"drafts":   map[string]interface{}{"2026-10-19": []interface{}{"my earlier thoughts"}},
+				},
+			},
+		}
+	}
+
+	Describe("Request logger", func() {
+		It("leaves dictated text, drafts and the user ID out of the logs by default", func() {
+			respEnv := skill.ProcessRequest(newEntryRequest("NONE"))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("my secret thoughts"))
+			Expect(logs.FilterMessage("Request started").Len()).To(Equal(1))
+			for _, entry := range logs.FilterMessage("Request started").All() {
+				logLine, e := json.Marshal(entry.ContextMap())
+				Expect(e).NotTo(HaveOccurred())
+				Expect(string(logLine)).To(ContainSubstring("redacted"))
+				Expect(string(logLine)).NotTo(ContainSubstring("my secret thoughts"))
+				Expect(string(logLine)).NotTo(ContainSubstring("my earlier thoughts"))
+				Expect(string(logLine)).NotTo(ContainSubstring("some-user"))
+				Expect(string(logLine)).NotTo(ContainSubstring("some-token"))
+			}
+		})
+
+		It("logs everything but the access token when redaction is disabled for debugging", func() {
+			skill.UseRequestInterceptors(NewRequestLogger(redact.Redactor{Disabled: true}))
+
+			skill.ProcessRequest(newEntryRequest("NONE"))
+
+			logLine, e := json.Marshal(logs.FilterMessage("Request started").All()[0].ContextMap())
+			Expect(e).NotTo(HaveOccurred())
+			Expect(string(logLine)).To(ContainSubstring("my secret thoughts"))
+			Expect(string(logLine)).To(ContainSubstring("some-user"))
+			Expect(string(logLine)).NotTo(ContainSubstring("some-token"))
+		})
+
+		It("doesn't modify the request", func() {
+			requestEnv := newEntryRequest("NONE")
+
+			skill.ProcessRequest(requestEnv)
+
+			Expect(requestEnv.Request.Intent.Slots["text"].Value).To(Equal("my secret thoughts"))
+			Expect(requestEnv.Session.Attributes).To(HaveKey("drafts"))
+			Expect(requestEnv.Session.User.AccessToken).To(Equal("some-token"))
+		})
+	})
+
+	Describe("Metrics", func() {
+		var emfOutput bytes.Buffer
+
+		BeforeEach(func() {
+			emfOutput.Reset()
+			latency := &LatencyMetrics{Metrics: &metrics.EMF{Writer: &emfOutput, Namespace: "AlexaJournal"}}
+			skill.UseRequestInterceptors(latency).UseResponseInterceptors(latency)
+		})
+
+		It("records the latency per intent", func() {
+			skill.ProcessRequest(newEntryRequest("NONE"))
+
+			var line map[string]interface{}
+			Expect(json.Unmarshal(emfOutput.Bytes(), &line)).To(Succeed())
+			Expect(line).To(HaveKeyWithValue("intent", "NewEntryIntent"))
+			Expect(line).To(HaveKeyWithValue("outcome", "success"))
+			Expect(line).To(HaveKey("request"))
+		})
+
+		It("records requests that panicked as errors", func() {
+			requestEnv := newEntryRequest("NONE")
+			requestEnv.Request.Intent.Name = "UnknownIntent"
+
+			skill.ProcessRequest(requestEnv)
+
+			var line map[string]interface{}
+			Expect(json.Unmarshal(emfOutput.Bytes(), &line)).To(Succeed())
+			Expect(line).To(HaveKeyWithValue("intent", "UnknownIntent"))
+			Expect(line).To(HaveKeyWithValue("outcome", "error"))
+		})
+	})
+
+	Describe("Tracing", func() {
+		var (
+			spans                  *tracetest.SpanRecorder
+			previousTracerProvider trace.TracerProvider
+		)
+
+		BeforeEach(func() {
+			spans = tracetest.NewSpanRecorder()
+			previousTracerProvider = otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+		})
+
+		AfterEach(func() {
+			otel.SetTracerProvider(previousTracerProvider)
+		})
+
+		It("traces the request and hands its trace to the error reporter", func() {
+			requestEnv := newEntryRequest("NONE")
+			requestEnv.Request.Intent.Name = "UnknownIntent"
+
+			skill.ProcessRequest(requestEnv)
+
+			Expect(spans.Ended()).To(HaveLen(1))
+			Expect(spans.Ended()[0].Name()).To(Equal("ProcessRequest"))
+			Expect(spans.Ended()[0].Attributes()).To(ContainElement(attribute.String("intent", "UnknownIntent")))
+			ctx, _, _ := errorReporter.VerifyWasCalledOnce().
+				ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).
+				GetCapturedArguments()
+			Expect(tracing.TraceID(ctx)).To(Equal(spans.Ended()[0].SpanContext().TraceID().String()))
+		})
+	})
+
+	Describe("AuditTrail", func() {
+		It("records added entries with the user ID", func() {
+			var auditLog bytes.Buffer
+			skill.UseResponseInterceptors(&AuditTrail{Writer: &auditLog})
+
+			skill.ProcessRequest(newEntryRequest("CONFIRMED"))
+
+			var record map[string]interface{}
+			Expect(json.Unmarshal(auditLog.Bytes(), &record)).To(Succeed())
+			Expect(record).To(HaveKeyWithValue("userId", "some-user"))
+			Expect(record).To(HaveKeyWithValue("action", "add"))
+			Expect(record).To(HaveKeyWithValue("intent", "NewEntryIntent"))
+			Expect(record["entryId"]).NotTo(BeEmpty())
+			Expect(record["timestamp"]).NotTo(BeEmpty())
+		})
+
+		It("records nothing when nothing changed", func() {
+			var auditLog bytes.Buffer
+			skill.UseResponseInterceptors(&AuditTrail{Writer: &auditLog})
+
+			skill.ProcessRequest(newEntryRequest("NONE"))
+
+			Expect(auditLog.String()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,243 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
+)
+
+type AnomalyKind string
+
+const (
+	MissingHeader    AnomalyKind = "missing header"
+	OutdatedHeader   AnomalyKind = "outdated header"
+	TooFewColumns    AnomalyKind = "too few columns"
+	TooManyColumns   AnomalyKind = "too many columns"
+	InvalidTimestamp AnomalyKind = "invalid timestamp"
+	EmptyDate        AnomalyKind = "empty date"
+	InvalidDate      AnomalyKind = "invalid date"
+	NonISODate       AnomalyKind = "date not in ISO format"
+	MissingID        AnomalyKind = "missing ID"
+	DuplicateID      AnomalyKind = "duplicate ID"
+	OutOfOrder       AnomalyKind = "out of order"
+)
+
+// fixableAnomalies are the anomalies Repair fixes. All others need to be fixed by hand.
+var fixableAnomalies = map[AnomalyKind]bool{
+	OutdatedHeader: true,
+	NonISODate:     true,
+	MissingID:      true,
+	DuplicateID:    true,
+}
+
+// Anomaly is a problem with a row of a journal's tabular data. Row starts at 0 for the header row.
+type Anomaly struct {
+	Row    int
+	Kind   AnomalyKind
+	Detail string
+}
+
+func (a Anomaly) Fixable() bool {
+	return fixableAnomalies[a.Kind]
+}
+
+func (a Anomaly) String() string {
+	if a.Detail == "" {
+		return fmt.Sprintf("row %v: %v", a.Row+1, a.Kind)
+	}
+	return fmt.Sprintf("row %v: %v: %v", a.Row+1, a.Kind, a.Detail)
+}
+
+// Inspect validates every row of data and returns all anomalies found. Empty rows are ignored.
+func Inspect(ctx context.Context, data TabularData) ([]Anomaly, error) {
+	rows, e := data.Rows(ctx)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not inspect journal")
+	}
+	return anomaliesIn(rows), nil
+}
+
+func anomaliesIn(rows [][]string) []Anomaly {
+	var anomalies []Anomaly
+	ids := make(map[string]int)
+	var previousDate date.Date
+	for i, parts := range rows {
+		if i == 0 {
+			switch {
+			case isOutdatedHeader(parts):
+				anomalies = append(anomalies, Anomaly{Row: i, Kind: OutdatedHeader})
+				continue
+			case isHeader(parts):
+				continue
+			default:
+				anomalies = append(anomalies, Anomaly{Row: i, Kind: MissingHeader})
+			}
+		}
+		if isEmptyRow(parts) {
+			continue
+		}
+		// Cells after the text are optional. Google Sheets doesn't return trailing empty cells anyway.
+		switch {
+		case len(parts) < legacyNumColumns:
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: TooFewColumns, Detail: fmt.Sprintf("%v columns", len(parts))})
+			continue
+		case len(parts) > numColumns && strings.Join(parts[numColumns:], "") != "":
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: TooManyColumns, Detail: fmt.Sprintf("%v columns", len(parts))})
+		}
+		if parts[timestampColumn] != "" {
+			if _, e := time.Parse(TimestampFormat, parts[timestampColumn]); e != nil {
+				anomalies = append(anomalies, Anomaly{Row: i, Kind: InvalidTimestamp, Detail: parts[timestampColumn]})
+			}
+		}
+		entryDate, e := date.AutoParse(parts[dateColumn])
+		switch {
+		case parts[dateColumn] == "":
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: EmptyDate})
+			continue
+		case e != nil:
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: InvalidDate, Detail: parts[dateColumn]})
+			continue
+		case entryDate.String() != parts[dateColumn]:
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: NonISODate, Detail: parts[dateColumn]})
+		}
+		if entryDate.Before(previousDate) {
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: OutOfOrder, Detail: fmt.Sprintf("%v after %v", entryDate, previousDate)})
+		}
+		previousDate = entryDate
+
+		id := cell(parts, idColumn)
+		switch {
+		case id == "":
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: MissingID})
+		case ids[id] != 0:
+			anomalies = append(anomalies, Anomaly{Row: i, Kind: DuplicateID, Detail: fmt.Sprintf("%v, same as row %v", id, ids[id])})
+		default:
+			ids[id] = i + 1
+		}
+	}
+	return anomalies
+}
+
+func isHeader(parts []string) bool {
+	return len(parts) >= numColumns && parts[timestampColumn] == Header[timestampColumn] && parts[dateColumn] == Header[dateColumn]
+}
+
+func isEmptyRow(parts []string) bool {
+	return strings.TrimSpace(strings.Join(parts, "")) == ""
+}
+
+type RepairOptions struct {
+	// Sort re-sorts entries by date and time. Rows that aren't valid entries stay where they are.
+	Sort bool
+	// DryRun only reports what would be repaired without changing anything.
+	DryRun bool
+}
+
+// RepairReport tells what Repair changed, or would change in a dry run.
+type RepairReport struct {
+	// Fixed are the anomalies that were fixed.
+	Fixed []Anomaly
+	// Unfixable are the anomalies that need to be fixed by hand.
+	Unfixable []Anomaly
+	// Updates are the rows that were rewritten, by row number.
+	Updates map[int][]string
+}
+
+// Repair fixes all fixable anomalies in data in place and optionally re-sorts the entries. All rows
+// are updated in one go. Updated rows are padded to the width of the widest row, so that no cells of the row that was
+// at the same position before are left behind, e.g. the mood of another entry.
+func Repair(ctx context.Context, data TabularData, options RepairOptions) (RepairReport, error) {
+	rows, e := data.Rows(ctx)
+	if e != nil {
+		return RepairReport{}, errors.Wrap(e, "Could not repair journal")
+	}
+	report := RepairReport{Updates: make(map[int][]string)}
+	repaired := make([][]string, len(rows))
+	copy(repaired, rows)
+
+	for _, anomaly := range anomaliesIn(rows) {
+		if anomaly.Kind == OutOfOrder && options.Sort {
+			report.Fixed = append(report.Fixed, anomaly)
+			continue
+		}
+		if !anomaly.Fixable() {
+			report.Unfixable = append(report.Unfixable, anomaly)
+			continue
+		}
+		row := padded(append([]string{}, repaired[anomaly.Row]...), idColumn+1)
+		switch anomaly.Kind {
+		case OutdatedHeader:
+			row = Header
+		case NonISODate:
+			row[dateColumn] = date.MustAutoParse(row[dateColumn]).String()
+		case MissingID, DuplicateID:
+			row[idColumn] = NewID()
+		}
+		repaired[anomaly.Row] = row
+		report.Fixed = append(report.Fixed, anomaly)
+	}
+
+	if options.Sort {
+		sortEntryRows(repaired)
+	}
+
+	width := 0
+	for _, row := range repaired {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	for i := range rows {
+		if strings.Join(rows[i], "\t") != strings.Join(repaired[i], "\t") {
+			report.Updates[i] = padded(repaired[i], width)
+		}
+	}
+	if options.DryRun || len(report.Updates) == 0 {
+		return report, nil
+	}
+	e = data.UpdateRows(ctx, report.Updates)
+	if e != nil {
+		return RepairReport{}, errors.Wrap(e, "Could not repair journal")
+	}
+	return report, nil
+}
+
+func padded(row []string, width int) []string {
+	if len(row) >= width {
+		return row
+	}
+	result := make([]string, width)
+	copy(result, row)
+	return result
+}
+
+// sortEntryRows sorts the entry rows by date and timestamp, leaving all other rows in place.
+func sortEntryRows(rows [][]string) {
+	var positions []int
+	var entryRows [][]string
+	for i, parts := range rows {
+		if i == 0 && (isHeader(parts) || isOutdatedHeader(parts)) {
+			continue
+		}
+		if isEntryRow(parts) {
+			positions = append(positions, i)
+			entryRows = append(entryRows, parts)
+		}
+	}
+	sort.SliceStable(entryRows, func(a, b int) bool {
+		dateA := date.MustAutoParse(entryRows[a][dateColumn])
+		dateB := date.MustAutoParse(entryRows[b][dateColumn])
+		if dateA != dateB {
+			return dateA.Before(dateB)
+		}
+		return entryRows[a][timestampColumn] < entryRows[b][timestampColumn]
+	})
+	for i, position := range positions {
+		rows[position] = entryRows[i]
+	}
+}
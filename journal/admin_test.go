@@ -0,0 +1,146 @@
+package journal_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+	"github.com/rickb777/date"
+)
+
+var _ = Describe("Admin", func() {
+	var data *tsv.StringBasedTabularData
+
+	BeforeEach(func() {
+		data = &tsv.StringBasedTabularData{}
+		data.AppendRows(ctx, [][]string{
+			{"timestamp", "date", "text"},
+			{"2019-01-02 10:00:00", "2019-01-02", "second", "id-2", "", "", ""},
+			{"yesterday", "01.01.2019", "first", "id-1", "", "", ""},
+			{"2019-01-03 10:00:00", "", "no date", "id-3", "", "", ""},
+			{"2019-01-03 10:00:00", "2019-01-03", "duplicate", "id-2", "", "", ""},
+			{"2019-01-04 10:00:00", "2019-01-04", "legacy"},
+			{"broken"},
+		})
+	})
+
+	Describe("Inspect", func() {
+		It("reports all anomalies", func() {
+			anomalies, e := j.Inspect(ctx, data)
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(anomalies).To(ConsistOf(
+				j.Anomaly{Row: 0, Kind: j.OutdatedHeader},
+				j.Anomaly{Row: 2, Kind: j.InvalidTimestamp, Detail: "yesterday"},
+				j.Anomaly{Row: 2, Kind: j.NonISODate, Detail: "01.01.2019"},
+				j.Anomaly{Row: 2, Kind: j.OutOfOrder, Detail: "2019-01-01 after 2019-01-02"},
+				j.Anomaly{Row: 3, Kind: j.EmptyDate},
+				j.Anomaly{Row: 4, Kind: j.DuplicateID, Detail: "id-2, same as row 2"},
+				j.Anomaly{Row: 5, Kind: j.MissingID},
+				j.Anomaly{Row: 6, Kind: j.TooFewColumns, Detail: "1 columns"},
+			))
+		})
+
+		It("reports nothing for a healthy journal", func() {
+			journal := j.Journal{Data: &tsv.StringBasedTabularData{}}
+			journal.AddEntry(ctx, date.MustAutoParse("2019-01-01"), "one")
+			journal.AddEntry(ctx, date.MustAutoParse("2019-01-02"), "two")
+
+			Expect(j.Inspect(ctx, journal.Data)).To(BeEmpty())
+		})
+
+		It("accepts entries without the trailing optional cells, which Google Sheets doesn't return", func() {
+			data := &tsv.StringBasedTabularData{}
+			data.AppendRows(ctx, [][]string{
+				j.Header,
+				{"2019-01-01 10:00:00", "2019-01-01", "one", "id-1"},
+				{"2019-01-02 10:00:00", "2019-01-02", "two", "id-2", "", "4"},
+			})
+
+			Expect(j.Inspect(ctx, data)).To(BeEmpty())
+		})
+	})
+
+	Describe("Repair", func() {
+		It("fixes what it can, sorts and reports the rest", func() {
+			report, e := j.Repair(ctx, data, j.RepairOptions{Sort: true})
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(report.Unfixable).To(ConsistOf(
+				j.Anomaly{Row: 2, Kind: j.InvalidTimestamp, Detail: "yesterday"},
+				j.Anomaly{Row: 3, Kind: j.EmptyDate},
+				j.Anomaly{Row: 6, Kind: j.TooFewColumns, Detail: "1 columns"},
+			))
+			Expect(report.Fixed).To(HaveLen(5))
+
+			rows, e := data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(rows[0]).To(Equal(j.Header))
+			Expect(rows[1]).To(Equal([]string{"yesterday", "2019-01-01", "first", "id-1", "", "", ""}))
+			Expect(rows[2]).To(Equal([]string{"2019-01-02 10:00:00", "2019-01-02", "second", "id-2", "", "", ""}))
+			Expect(rows[3][2]).To(Equal("no date"))
+			Expect(rows[4][2]).To(Equal("duplicate"))
+			Expect(rows[4][3]).NotTo(Equal("id-2"))
+			Expect(rows[5][2]).To(Equal("legacy"))
+			Expect(rows[5]).To(HaveLen(7))
+			Expect(rows[5][3]).NotTo(BeEmpty())
+			Expect(rows[6]).To(Equal([]string{"broken"}))
+
+			Expect(j.Inspect(ctx, data)).To(ConsistOf(
+				j.Anomaly{Row: 1, Kind: j.InvalidTimestamp, Detail: "yesterday"},
+				j.Anomaly{Row: 3, Kind: j.EmptyDate},
+				j.Anomaly{Row: 6, Kind: j.TooFewColumns, Detail: "1 columns"},
+			))
+		})
+
+		It("clears the cells of longer rows when sorting rows of different widths", func() {
+			data := &sheetLikeTabularData{}
+			data.AppendRows(ctx, [][]string{
+				j.Header,
+				{"2019-01-02 10:00:00", "2019-01-02", "second", "id-2", "", "4", "", "my note"},
+				{"2019-01-01 10:00:00", "2019-01-01", "first", "id-1"},
+			})
+
+			_, e := j.Repair(ctx, data, j.RepairOptions{Sort: true})
+
+			Expect(e).NotTo(HaveOccurred())
+			rows, e := data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(rows[1]).To(Equal([]string{"2019-01-01 10:00:00", "2019-01-01", "first", "id-1", "", "", "", ""}))
+			Expect(rows[2]).To(Equal([]string{"2019-01-02 10:00:00", "2019-01-02", "second", "id-2", "", "4", "", "my note"}))
+		})
+
+		It("doesn't change anything in a dry run", func() {
+			before, e := data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+
+			report, e := j.Repair(ctx, data, j.RepairOptions{Sort: true, DryRun: true})
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(report.Updates).NotTo(BeEmpty())
+			Expect(data.Rows(ctx)).To(Equal(before))
+		})
+	})
+})
+
+// sheetLikeTabularData updates rows like Google Sheets does: cells beyond an updated row's length keep their value.
+type sheetLikeTabularData struct {
+	tsv.StringBasedTabularData
+}
+
+func (td *sheetLikeTabularData) UpdateRows(ctx context.Context, updates map[int][]string) error {
+	rows, e := td.Rows(ctx)
+	if e != nil {
+		return e
+	}
+	merged := make(map[int][]string)
+	for i, row := range updates {
+		merged[i] = append([]string{}, row...)
+		if len(rows[i]) > len(row) {
+			merged[i] = append(merged[i], rows[i][len(row):]...)
+		}
+	}
+	return td.StringBasedTabularData.UpdateRows(ctx, merged)
+}
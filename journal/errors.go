@@ -0,0 +1,49 @@
+package journal
+
+import "github.com/pkg/errors"
+
+type EntryNotFoundError struct{ error }
+
+func NewEntryNotFoundError(id string) *EntryNotFoundError {
+	return &EntryNotFoundError{errors.Errorf("EntryNotFoundError. id: %v", id)}
+}
+func IsEntryNotFoundError(e error) bool {
+	_, is := e.(*EntryNotFoundError)
+	return is
+}
+
+// MalformedRowError describes a row in the journal that could not be read as an entry.
+type MalformedRowError struct {
+	error
+	Row  int
+	Kind AnomalyKind
+}
+
+func NewMalformedRowError(row int, kind AnomalyKind, detail string) *MalformedRowError {
+	return &MalformedRowError{
+		error: errors.Errorf("MalformedRowError. row: %v, kind: %v, detail: %v", row+1, kind, detail),
+		Row:   row,
+		Kind:  kind,
+	}
+}
+func IsMalformedRowError(e error) bool {
+	_, is := e.(*MalformedRowError)
+	return is
+}
+
+// MalformedRowsError tells that some rows were skipped, because they could not be read.
+type MalformedRowsError struct {
+	error
+	Rows []*MalformedRowError
+}
+
+func NewMalformedRowsError(rows []*MalformedRowError) *MalformedRowsError {
+	return &MalformedRowsError{
+		error: errors.Errorf("MalformedRowsError. %v rows could not be read. First: %v", len(rows), rows[0]),
+		Rows:  rows,
+	}
+}
+func IsMalformedRowsError(e error) bool {
+	_, is := e.(*MalformedRowsError)
+	return is
+}
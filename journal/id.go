@@ -0,0 +1,19 @@
+package journal
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/petergtz/alexa-journal/util"
+	"github.com/pkg/errors"
+)
+
+// NewID returns a random (version 4) UUID.
+func NewID() string {
+	var b [16]byte
+	_, e := rand.Read(b[:])
+	util.PanicOnError(errors.Wrap(e, "Could not read random bytes"))
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
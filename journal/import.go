@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ImportResult tells how many entries an import added and how many it skipped, because they existed already.
+type ImportResult struct {
+	Imported   int
+	Duplicates int
+}
+
+// ImportEntries appends all entries that aren't in the journal yet. Entries with the same date and text are
+// considered the same, so importing the same export twice doesn't duplicate entries. Rows are appended in
+// batches of batchSize. Entries keep their timestamp and ID if they have one.
+func (j *Journal) ImportEntries(ctx context.Context, entries []Entry, batchSize int) (ImportResult, error) {
+	if batchSize <= 0 {
+		return ImportResult{}, errors.Errorf("Invalid batch size %v", batchSize)
+	}
+	existingEntries, e := j.entries(ctx)
+	if e != nil {
+		return ImportResult{}, errors.Wrap(e, "Could not import entries")
+	}
+	existing := make(map[string]bool)
+	for _, entry := range existingEntries {
+		existing[importKeyOf(entry)] = true
+	}
+
+	empty, e := j.Data.Empty(ctx)
+	if e != nil {
+		return ImportResult{}, errors.Wrap(e, "Could not import entries")
+	}
+	var result ImportResult
+	var newRows [][]string
+	if empty {
+		newRows = append(newRows, Header)
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		key := importKeyOf(entry)
+		if existing[key] {
+			result.Duplicates++
+			continue
+		}
+		existing[key] = true
+		if entry.Timestamp.IsZero() {
+			entry.Timestamp = now
+		}
+		if entry.ID == "" {
+			entry.ID = NewID()
+		}
+		newRows = append(newRows, sliceFromEntry(entry))
+		result.Imported++
+	}
+
+	for len(newRows) > 0 {
+		batch := newRows
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		e := j.Data.AppendRows(ctx, batch)
+		if e != nil {
+			return ImportResult{}, errors.Wrapf(e, "Could not import batch of %v rows", len(batch))
+		}
+		newRows = newRows[len(batch):]
+	}
+	return result, nil
+}
+
+func importKeyOf(entry Entry) string {
+	return entry.EntryDate.String() + "\t" + strings.TrimSpace(entry.EntryText)
+}
@@ -0,0 +1,84 @@
+package journal_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+	"github.com/rickb777/date"
+)
+
+type batchCountingTabularData struct {
+	tsv.StringBasedTabularData
+	batchSizes []int
+}
+
+func (td *batchCountingTabularData) AppendRows(ctx context.Context, rows [][]string) error {
+	td.batchSizes = append(td.batchSizes, len(rows))
+	return td.StringBasedTabularData.AppendRows(ctx, rows)
+}
+
+var _ = Describe("ImportEntries", func() {
+	var (
+		data    *batchCountingTabularData
+		journal j.Journal
+	)
+
+	BeforeEach(func() {
+		data = &batchCountingTabularData{}
+		journal = j.Journal{Data: data}
+	})
+
+	It("adds a header to an empty journal and keeps timestamps, IDs and tags", func() {
+		timestamp := time.Date(2015, 6, 1, 20, 15, 0, 0, time.UTC)
+		result, e := journal.ImportEntries(ctx, []j.Entry{
+			{EntryDate: date.MustAutoParse("2015-06-01"), EntryText: "imported", Timestamp: timestamp, ID: "some-id", Tags: []string{"travel"}},
+		}, 100)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(result).To(Equal(j.ImportResult{Imported: 1}))
+		rows, e := data.Rows(ctx)
+		Expect(e).NotTo(HaveOccurred())
+		Expect(rows[0]).To(Equal(j.Header))
+
+		entry, e := journal.GetEntryByID(ctx, "some-id")
+		Expect(e).NotTo(HaveOccurred())
+		Expect(entry.EntryText).To(Equal("imported"))
+		Expect(entry.Timestamp).To(Equal(timestamp))
+		Expect(entry.Tags).To(Equal([]string{"travel"}))
+	})
+
+	It("skips entries with the same date and text as existing or other imported entries", func() {
+		journal.AddEntry(ctx, date.MustAutoParse("2015-06-01"), "already there")
+
+		result, e := journal.ImportEntries(ctx, []j.Entry{
+			{EntryDate: date.MustAutoParse("2015-06-01"), EntryText: "already there "},
+			{EntryDate: date.MustAutoParse("2015-06-02"), EntryText: "already there"},
+			{EntryDate: date.MustAutoParse("2015-06-03"), EntryText: "new"},
+			{EntryDate: date.MustAutoParse("2015-06-03"), EntryText: "new"},
+		}, 100)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(result).To(Equal(j.ImportResult{Imported: 2, Duplicates: 2}))
+		entries, e := journal.GetEntries(ctx, "2015-06")
+		Expect(e).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[2].ID).NotTo(BeEmpty())
+	})
+
+	It("appends rows in batches", func() {
+		var entries []j.Entry
+		for i := 1; i <= 5; i++ {
+			entries = append(entries, j.Entry{EntryDate: date.New(2015, 6, i), EntryText: "text"})
+		}
+
+		result, e := journal.ImportEntries(ctx, entries, 2)
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(result.Imported).To(Equal(5))
+		Expect(data.batchSizes).To(Equal([]int{2, 2, 2}))
+	})
+})
@@ -1,7 +1,11 @@
 package journal
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,17 +17,29 @@ import (
 type Journal struct {
 	Data  TabularData
 	Index Index
+	// StorageID identifies where Data is stored, e.g. a spreadsheet ID, so that it can be found again without looking
+	// it up. It's empty if the storage has no such ID.
+	StorageID string
+
+	parseReport ParseReport
 }
 
+// TabularData is the storage of a journal. Implementations should give up when ctx is done,
+// because the skill only has a few seconds to respond.
 type TabularData interface {
-	Rows() ([][]string, error)
-	AppendRow(row []string) error
-	Empty() (bool, error)
-	DeleteRow(rowNum int) error
+	Rows(ctx context.Context) ([][]string, error)
+	AppendRow(ctx context.Context, row []string) error
+	// AppendRows appends all rows at once. It's used for bulk imports, where appending row by row would be too slow.
+	AppendRows(ctx context.Context, rows [][]string) error
+	Empty(ctx context.Context) (bool, error)
+	DeleteRow(ctx context.Context, rowNum int) error
+	// UpdateRows replaces the rows at the given row numbers. Implementations should
+	// apply all updates in one go, because it's used to repair whole columns.
+	UpdateRows(ctx context.Context, rows map[int][]string) error
 }
 type Index interface {
 	Add(id string, text string)
-	Search(query string) []Rank
+	Search(ctx context.Context, query string) ([]Rank, error)
 }
 
 type Rank struct {
@@ -35,62 +51,285 @@ type Entry struct {
 	Timestamp time.Time
 	EntryDate date.Date
 	EntryText string
+	ID        string
+	Tags      []string
+	// Mood is the user's rating of the day from MinMood to MaxMood, or 0 if not rated.
+	Mood int
+	// Items are the items of a list entry, such as a gratitude list, or empty for free text entries.
+	// EntryText of a list entry contains all items, so list entries can be searched like any other entry.
+	Items []string
 }
 
-func entryFromSlice(parts []string) Entry {
-	timestamp, e := time.Parse(TimestampFormat, parts[0])
+const (
+	timestampColumn = iota
+	dateColumn
+	textColumn
+	idColumn
+	tagsColumn
+	moodColumn
+	itemsColumn
+
+	numColumns
+)
+
+// legacyNumColumns is the number of columns journals had before entries got IDs.
+const legacyNumColumns = 3
+
+var Header = []string{"timestamp", "date", "text", "id", "tags", "mood", "items"}
+
+const tagSeparator = ","
+
+// entryFromSlice parses a row. It returns a *MalformedRowError with the given rowNum if the row can't be read.
+func entryFromSlice(rowNum int, parts []string) (Entry, error) {
+	if len(parts) < legacyNumColumns {
+		return Entry{}, NewMalformedRowError(rowNum, TooFewColumns, fmt.Sprintf("%v columns", len(parts)))
+	}
+	if parts[dateColumn] == "" {
+		return Entry{}, NewMalformedRowError(rowNum, EmptyDate, "")
+	}
+	entryDate, e := date.AutoParse(parts[dateColumn])
+	if e != nil {
+		return Entry{}, NewMalformedRowError(rowNum, InvalidDate, parts[dateColumn])
+	}
+	timestamp, e := time.Parse(TimestampFormat, parts[timestampColumn])
 	if e != nil {
 		// Let's be more forgiving for the cases where a user messed up some data in the sheet
 		timestamp = time.Time{}
 	}
-	if parts[1] == "" {
-		panic(errors.New("parts[1] must not be empty"))
+	return Entry{
+		Timestamp: timestamp,
+		EntryDate: entryDate,
+		EntryText: parts[textColumn],
+		ID:        cell(parts, idColumn),
+		Tags:      tagsFrom(cell(parts, tagsColumn)),
+		Mood:      moodFrom(cell(parts, moodColumn)),
+		Items:     itemsFrom(cell(parts, itemsColumn)),
+	}, nil
+}
+
+func sliceFromEntry(entry Entry) []string {
+	timestamp := ""
+	if !entry.Timestamp.IsZero() {
+		timestamp = entry.Timestamp.Format(TimestampFormat)
+	}
+	mood := ""
+	if entry.Mood != 0 {
+		mood = strconv.Itoa(entry.Mood)
+	}
+	return []string{timestamp, entry.EntryDate.String(), entry.EntryText, entry.ID, strings.Join(entry.Tags, tagSeparator), mood, itemsCellFrom(entry.Items)}
+}
+
+func cell(parts []string, column int) string {
+	if column >= len(parts) {
+		return ""
 	}
-	return Entry{timestamp, date.MustAutoParse(parts[1]), parts[2]}
+	return parts[column]
+}
+
+func tagsFrom(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, tagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// itemsFrom parses the items of a list entry, which are stored as JSON array. Malformed items are ignored, so a user
+// who edited the sheet by hand can still read the entry's text.
+func itemsFrom(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var items []string
+	if e := json.Unmarshal([]byte(s), &items); e != nil {
+		return nil
+	}
+	return items
+}
+
+func itemsCellFrom(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	b, e := json.Marshal(items)
+	if e != nil {
+		panic(errors.Wrap(e, "Could not marshal items"))
+	}
+	return string(b)
 }
 
 const TimestampFormat = "2006-01-02 15:04:05"
 
-func (j *Journal) AddEntry(entryDate date.Date, text string) error {
-	empty, e := j.Data.Empty()
+// AddEntry adds a new entry and returns its ID.
+func (j *Journal) AddEntry(ctx context.Context, entryDate date.Date, text string) (string, error) {
+	return j.addEntry(ctx, Entry{EntryDate: entryDate, EntryText: text})
+}
+
+// AddListEntry adds a new list entry, such as a gratitude list, and returns its ID.
+func (j *Journal) AddListEntry(ctx context.Context, entryDate date.Date, items []string) (string, error) {
+	return j.addEntry(ctx, Entry{EntryDate: entryDate, EntryText: strings.Join(items, ". "), Items: items})
+}
+
+func (j *Journal) addEntry(ctx context.Context, entry Entry) (string, error) {
+	e := j.AssignMissingIDs(ctx)
+	if e != nil {
+		return "", errors.Wrap(e, "Could not add entry")
+	}
+	empty, e := j.Data.Empty(ctx)
 	if e != nil {
-		return errors.Wrap(e, "Could not add entry")
+		return "", errors.Wrap(e, "Could not add entry")
 	}
 	if empty {
-		e := j.Data.AppendRow([]string{"timestamp", "date", "text"})
+		e := j.Data.AppendRow(ctx, Header)
 		if e != nil {
-			return errors.Wrap(e, "Could not add entry")
+			return "", errors.Wrap(e, "Could not add entry")
 		}
 	}
-	e = j.Data.AppendRow([]string{time.Now().Format(TimestampFormat), entryDate.String(), text})
+	entry.Timestamp = time.Now()
+	entry.ID = NewID()
+	e = j.Data.AppendRow(ctx, sliceFromEntry(entry))
 	if e != nil {
-		return errors.Wrap(e, "Could not add entry")
+		return "", errors.Wrap(e, "Could not add entry")
 	}
-	return nil
+	return entry.ID, nil
 }
 
-func (j *Journal) GetEntry(entryDate date.Date) (string, error) {
-	var entriesFound []Entry
-	rows, e := j.Data.Rows()
+func isOutdatedHeader(parts []string) bool {
+	return len(parts) >= legacyNumColumns && len(parts) < numColumns &&
+		parts[timestampColumn] == Header[timestampColumn] && parts[dateColumn] == Header[dateColumn]
+}
+
+// CountEntries returns the number of rows in data that are entries. Unlike reading the entries, it doesn't parse
+// them, so it's a cheap way to find out whether a journal is empty.
+func CountEntries(ctx context.Context, data TabularData) (int, error) {
+	rows, e := data.Rows(ctx)
 	if e != nil {
-		return "", errors.Wrap(e, "Could not get entry")
+		return 0, errors.Wrap(e, "Could not count entries")
 	}
+	count := 0
 	for _, parts := range rows {
-		if len(parts) != 3 {
-			continue
+		if isEntryRow(parts) {
+			count++
 		}
-		if parts[1] == "" {
+	}
+	return count, nil
+}
+
+func isEntryRow(parts []string) bool {
+	if len(parts) < legacyNumColumns || parts[dateColumn] == "" {
+		return false
+	}
+	_, e := date.AutoParse(parts[dateColumn])
+	return e == nil
+}
+
+// isHeaderLike tells whether a row is meant to be a header, even if it's outdated.
+func isHeaderLike(parts []string) bool {
+	return len(parts) >= legacyNumColumns && parts[dateColumn] == Header[dateColumn]
+}
+
+type entryRow struct {
+	rowNum int
+	Entry
+	// extraCells are the cells beyond the journal's columns, e.g. notes the user added by hand. They are kept when
+	// the row is rewritten.
+	extraCells []string
+}
+
+// entryRows parses all rows and returns the entries along with their row numbers. Rows that can't be read are
+// skipped, so that one malformed row doesn't make the whole journal unusable. They are collected in the
+// journal's ParseReport instead. Entries written before entries had IDs have an empty ID until AssignMissingIDs
+// is called. Reading never writes to the journal.
+func (j *Journal) entryRows(ctx context.Context) ([]entryRow, error) {
+	rows, e := j.Data.Rows(ctx)
+	if e != nil {
+		return nil, e
+	}
+	var result []entryRow
+	var report ParseReport
+	for i, parts := range rows {
+		if isEmptyRow(parts) || (i == 0 && isHeaderLike(parts)) {
 			continue
 		}
-		d, e := date.AutoParse(parts[1])
+		entry, e := entryFromSlice(i, parts)
 		if e != nil {
+			report.Errors = append(report.Errors, e.(*MalformedRowError))
 			continue
 		}
-		if d == entryDate {
-			entriesFound = append(entriesFound, entryFromSlice(parts))
+		var extraCells []string
+		if len(parts) > numColumns {
+			extraCells = parts[numColumns:]
 		}
+		result = append(result, entryRow{rowNum: i, Entry: entry, extraCells: extraCells})
+	}
+	j.parseReport = report
+	return result, nil
+}
+
+// AssignMissingIDs writes a new ID to every entry written before entries had IDs, all in one go. Such entries can't be
+// addressed by ID before, so it must be called before handing out IDs of entries that might be legacy ones, e.g.
+// before asking the user to confirm their deletion. Rows are otherwise kept as they are.
+func (j *Journal) AssignMissingIDs(ctx context.Context) error {
+	rows, e := j.Data.Rows(ctx)
+	if e != nil {
+		return errors.Wrap(e, "Could not assign missing IDs")
+	}
+	updates := make(map[int][]string)
+	for i, parts := range rows {
+		if (i == 0 && isHeaderLike(parts)) || !isEntryRow(parts) || cell(parts, idColumn) != "" {
+			continue
+		}
+		row := make([]string, idColumn+1)
+		copy(row, parts)
+		if len(parts) > len(row) {
+			row = append(row, parts[len(row):]...)
+		}
+		row[idColumn] = NewID()
+		updates[i] = row
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return errors.Wrap(j.Data.UpdateRows(ctx, updates), "Could not assign missing IDs")
+}
+
+func (j *Journal) entries(ctx context.Context) ([]Entry, error) {
+	entryRows, e := j.entryRows(ctx)
+	if e != nil {
+		return nil, e
+	}
+	entries := make([]Entry, len(entryRows))
+	for i, entryRow := range entryRows {
+		entries[i] = entryRow.Entry
+	}
+	return entries, nil
+}
+
+// ParseReport tells which rows couldn't be read the last time the journal was read.
+func (j *Journal) ParseReport() ParseReport {
+	return j.parseReport
+}
+
+// ParseReport collects the rows that had to be skipped while reading the journal.
+type ParseReport struct {
+	Errors []*MalformedRowError
+}
+
+// Err returns a *MalformedRowsError if any rows were skipped, nil otherwise.
+func (r ParseReport) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return NewMalformedRowsError(r.Errors)
+}
+
+func (j *Journal) GetEntry(ctx context.Context, entryDate date.Date) (string, error) {
+	entriesFound, e := j.GetEntriesOn(ctx, entryDate)
+	if e != nil {
+		return "", errors.Wrap(e, "Could not get entry")
 	}
-	sort.SliceStable(entriesFound, ByTimestamp(entriesFound))
 	var texts []string
 	for _, entry := range entriesFound {
 		texts = append(texts, entry.EntryText)
@@ -98,76 +337,132 @@ func (j *Journal) GetEntry(entryDate date.Date) (string, error) {
 	return strings.Join(texts, ". "), nil
 }
 
+// GetEntriesOn returns all entries for entryDate, ordered by the time they were written.
+func (j *Journal) GetEntriesOn(ctx context.Context, entryDate date.Date) ([]Entry, error) {
+	var entriesFound []Entry
+	entries, e := j.entries(ctx)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not get entries")
+	}
+	for _, entry := range entries {
+		if entry.EntryDate == entryDate {
+			entriesFound = append(entriesFound, entry)
+		}
+	}
+	sort.SliceStable(entriesFound, ByTimestamp(entriesFound))
+	return entriesFound, nil
+}
+
 func ByTimestamp(entriesFound []Entry) func(i, j int) bool {
 	return func(i int, j int) bool { return entriesFound[i].Timestamp.Before(entriesFound[j].Timestamp) }
 }
 
-func (j *Journal) DeleteEntry(entryDate date.Date) error {
-	rows, e := j.Data.Rows()
+// GetEntryByID returns the entry with the given id.
+func (j *Journal) GetEntryByID(ctx context.Context, id string) (Entry, error) {
+	entryRow, e := j.entryRowOf(ctx, id)
 	if e != nil {
-		return errors.Wrap(e, "Could not get data rows")
+		return Entry{}, e
 	}
-	for i, parts := range rows {
-		if len(parts) != 3 {
-			continue
-		}
-		if parts[1] == "" {
-			continue
-		}
-		d, e := date.AutoParse(parts[1])
-		if e != nil {
-			continue
-		}
-		if d == entryDate {
-			e := j.Data.DeleteRow(i)
-			if e != nil {
-				return errors.Wrapf(e, "Could not delete row %v in data", i)
+	return entryRow.Entry, nil
+}
+
+// DeleteEntry deletes the entry with the given id. The row is looked up right before
+// deletion, so that rows inserted or removed by hand in the meantime don't matter.
+func (j *Journal) DeleteEntry(ctx context.Context, id string) error {
+	entryRow, e := j.entryRowOf(ctx, id)
+	if e != nil {
+		return e
+	}
+	e = j.Data.DeleteRow(ctx, entryRow.rowNum)
+	if e != nil {
+		return errors.Wrapf(e, "Could not delete row %v in data", entryRow.rowNum)
+	}
+	return nil
+}
+
+// EditEntry replaces the text of the entry with the given id.
+func (j *Journal) EditEntry(ctx context.Context, id string, text string) error {
+	return j.updateEntry(ctx, id, func(entry *Entry) { entry.EntryText = text })
+}
+
+// TagEntry adds tags to the entry with the given id. Tags the entry already has are ignored.
+func (j *Journal) TagEntry(ctx context.Context, id string, tags ...string) error {
+	return j.updateEntry(ctx, id, func(entry *Entry) {
+		for _, tag := range tags {
+			if !hasTag(*entry, tag) {
+				entry.Tags = append(entry.Tags, strings.TrimSpace(tag))
 			}
 		}
+	})
+}
+
+func (j *Journal) updateEntry(ctx context.Context, id string, update func(entry *Entry)) error {
+	entryRow, e := j.entryRowOf(ctx, id)
+	if e != nil {
+		return e
+	}
+	update(&entryRow.Entry)
+	row := append(sliceFromEntry(entryRow.Entry), entryRow.extraCells...)
+	e = j.Data.UpdateRows(ctx, map[int][]string{entryRow.rowNum: row})
+	if e != nil {
+		return errors.Wrapf(e, "Could not update row %v in data", entryRow.rowNum)
 	}
 	return nil
 }
 
-func (j *Journal) GetClosestEntry(entryDate date.Date) (Entry, error) {
+func (j *Journal) entryRowOf(ctx context.Context, id string) (entryRow, error) {
+	if id == "" {
+		return entryRow{}, NewEntryNotFoundError(id)
+	}
+	entryRows, e := j.entryRows(ctx)
+	if e != nil {
+		return entryRow{}, errors.Wrap(e, "Could not get data rows")
+	}
+	for _, entryRow := range entryRows {
+		if entryRow.ID == id {
+			return entryRow, nil
+		}
+	}
+	return entryRow{}, NewEntryNotFoundError(id)
+}
+
+func hasTag(entry Entry, tag string) bool {
+	for _, t := range entry.Tags {
+		if strings.EqualFold(t, strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *Journal) GetClosestEntry(ctx context.Context, entryDate date.Date) (Entry, error) {
 	var closestPositiveEntry, closestNegativeEntry *Entry
 
 	closestPositiveDiff := -(1 << 30)
 	closestNegativeDiff := 1 << 30
-	rows, e := j.Data.Rows()
+	entries, e := j.entries(ctx)
 	if e != nil {
 		return Entry{}, errors.Wrap(e, "Could not get closest entry")
 	}
-	for _, parts := range rows {
-		if len(parts) != 3 {
-			continue
-		}
-		if parts[1] == "" {
+	for _, entry := range entries {
+		if entry.Timestamp.IsZero() {
 			continue
 		}
-		_, e := time.Parse(TimestampFormat, parts[0])
-		if e != nil {
-			continue
-		}
-		d, e := date.AutoParse(parts[1])
-		if e != nil {
-			continue
-		}
-		diff := entryDate.Sub(d)
+		entry := entry
+		diff := entryDate.Sub(entry.EntryDate)
 
 		if diff == 0 {
-			return entryFromSlice(parts), nil
+			return entry, nil
 		}
 		if diff > 0 {
 			if int(diff) < closestNegativeDiff {
 				closestNegativeDiff = int(diff)
-				entry := entryFromSlice(parts)
 				closestNegativeEntry = &entry
 			}
 		}
 		if diff < 0 {
 			if int(diff) > closestPositiveDiff {
 				closestPositiveDiff = int(diff)
-				entry := entryFromSlice(parts)
 				closestPositiveEntry = &entry
 			}
 		}
@@ -181,55 +476,94 @@ func (j *Journal) GetClosestEntry(entryDate date.Date) (Entry, error) {
 	return Entry{}, nil
 }
 
-func (j *Journal) GetEntries(timeRange string) ([]Entry, error) {
+// GetEntries returns the entries in timeRange, which is a date prefix such as "2019" or "2019-03".
+// An empty timeRange covers the whole journal.
+func (j *Journal) GetEntries(ctx context.Context, timeRange string) ([]Entry, error) {
 	var result []Entry
-	rows, e := j.Data.Rows()
+	entries, e := j.entries(ctx)
 	if e != nil {
 		return nil, errors.Wrap(e, "Could not get entries")
 	}
-	for _, parts := range rows {
-		if len(parts) != 3 {
-			continue
-		}
-		if strings.HasPrefix(parts[1], timeRange) {
-			result = append(result, entryFromSlice(parts))
+	for _, entry := range entries {
+		if inTimeRange(entry, timeRange) {
+			result = append(result, entry)
 		}
 	}
 	return result, nil
 }
 
-func (j *Journal) SearchFor(query string) ([]Entry, error) {
-	lookup := make(map[string]string)
-	rows, e := j.Data.Rows()
+// inTimeRange matches against the entry's date in ISO format, so that entries with dates written
+// in other formats in the sheet are found as well.
+func inTimeRange(entry Entry, timeRange string) bool {
+	return strings.HasPrefix(entry.EntryDate.String(), timeRange)
+}
+
+func (j *Journal) SearchFor(ctx context.Context, query string) ([]Entry, error) {
+	lookup := make(map[string]Entry)
+	entryRows, e := j.entryRows(ctx)
 	if e != nil {
 		return nil, errors.Wrap(e, "Could not get entries")
 	}
-	for _, parts := range rows {
-		if len(parts) != 3 {
-			continue
-		}
-		if parts[1] != "" {
-			if _, e := date.AutoParse(parts[1]); e == nil {
-				j.Index.Add(parts[1], parts[2])
-				lookup[parts[1]] = parts[2]
-			}
-		}
+	// Entries are indexed by row, because entries written before entries had IDs don't have one.
+	for _, entryRow := range entryRows {
+		key := strconv.Itoa(entryRow.rowNum)
+		j.Index.Add(key, entryRow.EntryText)
+		lookup[key] = entryRow.Entry
+	}
+	hits, e := j.Index.Search(ctx, query)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not search entries")
 	}
-	hits := j.Index.Search(query)
 
-	result := make([]Entry, len(hits))
-	i := 0
+	var result []Entry
 	for _, hit := range hits {
-		result[i] = Entry{
-			EntryDate: date.MustAutoParse(hit.Result),
-			EntryText: lookup[hit.Result],
+		if entry, exists := lookup[hit.Result]; exists {
+			result = append(result, entry)
 		}
-		i++
 	}
-	sort.Slice(result, ByEntryDate(result))
+	sort.SliceStable(result, ByEntryDate(result))
 	return result, nil
 }
 
 func ByEntryDate(entries []Entry) func(i, j int) bool {
 	return func(i int, j int) bool { return entries[i].EntryDate.Before(entries[j].EntryDate) }
 }
+
+// GetEntriesOnDayOfYear returns the entries written for the given month and day in any year before
+// the given year, ordered from the oldest to the newest.
+func (j *Journal) GetEntriesOnDayOfYear(ctx context.Context, month time.Month, day int, beforeYear int) ([]Entry, error) {
+	var result []Entry
+	entries, e := j.entries(ctx)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not get entries")
+	}
+	for _, entry := range entries {
+		d := entry.EntryDate
+		if d.Month() == month && d.Day() == day && d.Year() < beforeYear {
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].EntryDate == result[j].EntryDate {
+			return result[i].Timestamp.Before(result[j].Timestamp)
+		}
+		return result[i].EntryDate.Before(result[j].EntryDate)
+	})
+	return result, nil
+}
+
+// GetEntriesWithTag returns the entries in timeRange that are tagged with tag. An empty timeRange
+// covers the whole journal, an empty tag matches all entries.
+func (j *Journal) GetEntriesWithTag(ctx context.Context, timeRange string, tag string) ([]Entry, error) {
+	var result []Entry
+	entries, e := j.GetEntries(ctx, timeRange)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not get entries")
+	}
+	for _, entry := range entries {
+		if tag == "" || hasTag(entry, tag) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
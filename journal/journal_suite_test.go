@@ -1,12 +1,16 @@
 package journal_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+// ctx is the context used in all journal tests. They don't need deadlines, because the test data is in memory.
+var ctx = context.Background()
+
 func TestDrive(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Journal Test Suite")
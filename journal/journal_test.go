@@ -6,8 +6,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/search/custom"
 	"github.com/petergtz/alexa-journal/tsv"
 	"github.com/rickb777/date"
+	"go.uber.org/zap"
 )
 
 var _ = Describe("Journal", func() {
@@ -27,62 +29,353 @@ var _ = Describe("Journal", func() {
 
 	Describe("GetEntry", func() {
 		It("can find entry", func() {
-			journal.AddEntry(date.MustAutoParse("1994-08-20"), "Example text")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "Example text")
 
-			Expect(journal.GetEntry(date.MustAutoParse("1994-08-20"))).To(Equal("Example text"))
+			Expect(journal.GetEntry(ctx, date.MustAutoParse("1994-08-20"))).To(Equal("Example text"))
 		})
 
 		It("concats multiple entries with same date", func() {
-			journal.AddEntry(date.MustAutoParse("1994-08-20"), "one")
-			journal.AddEntry(date.MustAutoParse("1994-08-20"), "two")
-			journal.AddEntry(date.MustAutoParse("1994-08-20"), "three")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "two")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "three")
 
-			Expect(journal.GetEntry(date.MustAutoParse("1994-08-20"))).To(Equal("one. two. three"))
+			Expect(journal.GetEntry(ctx, date.MustAutoParse("1994-08-20"))).To(Equal("one. two. three"))
 		})
 
 	})
 
 	Describe("GetEntries", func() {
 		It("can read rows even when timestamp is empty", func() {
-			journal.Data.AppendRow([]string{"", "1994-08-20", "one"})
+			journal.Data.AppendRow(ctx, []string{"", "1994-08-20", "one"})
 
-			Expect(journal.GetEntries("1994-08")).
-				To(ContainElement(j.Entry{time.Time{}, date.MustAutoParse("1994-08-20"), "one"}))
+			entries, e := journal.GetEntries(ctx, "1994-08")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Timestamp).To(Equal(time.Time{}))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("1994-08-20")))
+			Expect(entries[0].EntryText).To(Equal("one"))
 		})
 
 		It("can read rows even when timestamp is messed up", func() {
-			journal.Data.AppendRow([]string{"sdjfh", "1994-08-20", "one"})
+			journal.Data.AppendRow(ctx, []string{"sdjfh", "1994-08-20", "one"})
+
+			entries, e := journal.GetEntries(ctx, "1994-08")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Timestamp).To(Equal(time.Time{}))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("1994-08-20")))
+			Expect(entries[0].EntryText).To(Equal("one"))
+		})
+	})
+
+	Describe("CountEntries", func() {
+		It("counts the entry rows, but not the header or empty and malformed rows", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			journal.Data.AppendRow(ctx, []string{"", "", ""})
+			journal.Data.AppendRow(ctx, []string{"", "not a date", "two"})
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-21"), "three")
+
+			Expect(j.CountEntries(ctx, journal.Data)).To(Equal(2))
+		})
 
-			Expect(journal.GetEntries("1994-08")).
-				To(ContainElement(j.Entry{time.Time{}, date.MustAutoParse("1994-08-20"), "one"}))
+		It("counts nothing in an empty journal", func() {
+			Expect(j.CountEntries(ctx, journal.Data)).To(Equal(0))
+		})
+	})
+
+	Describe("Entry IDs", func() {
+		It("writes a unique ID for every new entry", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "two")
+
+			entries, e := journal.GetEntriesOn(ctx, date.MustAutoParse("1994-08-20"))
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].ID).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+			Expect(entries[0].ID).NotTo(Equal(entries[1].ID))
+		})
+
+		It("doesn't write to the journal when reading legacy rows, which have no ID until one is assigned", func() {
+			journal.Data.AppendRow(ctx, []string{"timestamp", "date", "text"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "one"})
+			rowsBefore, e := journal.Data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+
+			entries, e := journal.GetEntries(ctx, "1994-08")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries[0].ID).To(BeEmpty())
+			Expect(journal.Data.Rows(ctx)).To(Equal(rowsBefore))
+			Expect(j.IsEntryNotFoundError(journal.DeleteEntry(ctx, ""))).To(BeTrue())
+		})
+
+		It("assigns IDs to legacy rows in one go and keeps cells beyond the journal's columns", func() {
+			journal.Data.AppendRow(ctx, []string{"timestamp", "date", "text"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "one"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "one", "", "", "", "", "my note"})
+
+			Expect(journal.AssignMissingIDs(ctx)).To(Succeed())
+
+			rows, e := journal.Data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(rows[0]).To(Equal([]string{"timestamp", "date", "text"}))
+			Expect(rows[1][3]).To(MatchRegexp(`^[0-9a-f-]{36}$`))
+			Expect(rows[2][3]).To(MatchRegexp(`^[0-9a-f-]{36}$`))
+			Expect(rows[1][3]).NotTo(Equal(rows[2][3]))
+			Expect(rows[2]).To(Equal([]string{"2019-01-01 10:00:00", "1994-08-20", "one", rows[2][3], "", "", "", "my note"}))
+		})
+
+		It("assigns IDs to legacy rows before adding an entry", func() {
+			journal.Data.AppendRow(ctx, []string{"timestamp", "date", "text"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "one"})
+
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-21"), "two")
+
+			entries, e := journal.GetEntries(ctx, "1994-08")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries[0].ID).NotTo(BeEmpty())
+			Expect(entries[1].ID).NotTo(BeEmpty())
+		})
+
+		It("deletes the confirmed legacy entry even after the sheet was edited by hand", func() {
+			journal.Data.AppendRow(ctx, []string{"timestamp", "date", "text"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "same"})
+			journal.Data.AppendRow(ctx, []string{"2019-01-01 10:00:00", "1994-08-20", "same"})
+			Expect(journal.AssignMissingIDs(ctx)).To(Succeed())
+			entries, e := journal.GetEntriesOn(ctx, date.MustAutoParse("1994-08-20"))
+			Expect(e).NotTo(HaveOccurred())
+			rows, e := journal.Data.Rows(ctx)
+			Expect(e).NotTo(HaveOccurred())
+
+			// The user fixes a typo in the second entry and deletes the first one by hand.
+			edited := append([]string{}, rows[2]...)
+			edited[2] = "same, but edited"
+			Expect(journal.Data.UpdateRows(ctx, map[int][]string{2: edited})).To(Succeed())
+			Expect(journal.Data.DeleteRow(ctx, 1)).To(Succeed())
+
+			Expect(j.IsEntryNotFoundError(journal.DeleteEntry(ctx, entries[0].ID))).To(BeTrue())
+			Expect(journal.DeleteEntry(ctx, entries[1].ID)).To(Succeed())
+			Expect(journal.GetEntries(ctx, "")).To(BeEmpty())
+		})
+
+		It("deletes by ID even when rows were inserted by hand in the meantime", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-21"), "two")
+			entries, e := journal.GetEntriesOn(ctx, date.MustAutoParse("1994-08-21"))
+			Expect(e).NotTo(HaveOccurred())
+
+			journal.Data.DeleteRow(ctx, 0)
+
+			Expect(journal.DeleteEntry(ctx, entries[0].ID)).To(Succeed())
+			Expect(journal.GetEntry(ctx, date.MustAutoParse("1994-08-21"))).To(BeEmpty())
+			Expect(journal.GetEntry(ctx, date.MustAutoParse("1994-08-20"))).To(Equal("one"))
+		})
+
+		It("returns an EntryNotFoundError for unknown IDs", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+
+			e := journal.DeleteEntry(ctx, "unknown")
+			Expect(j.IsEntryNotFoundError(e)).To(BeTrue())
+		})
+
+		It("edits and tags by ID", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			entries, e := journal.GetEntriesOn(ctx, date.MustAutoParse("1994-08-20"))
+			Expect(e).NotTo(HaveOccurred())
+
+			Expect(journal.EditEntry(ctx, entries[0].ID, "changed")).To(Succeed())
+			Expect(journal.TagEntry(ctx, entries[0].ID, "holiday", "family")).To(Succeed())
+			Expect(journal.TagEntry(ctx, entries[0].ID, "Holiday")).To(Succeed())
+
+			entry, e := journal.GetEntryByID(ctx, entries[0].ID)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.EntryText).To(Equal("changed"))
+			Expect(entry.Tags).To(Equal([]string{"holiday", "family"}))
 		})
 	})
 
 	Describe("GetClosestEntry", func() {
 		It("can find entry", func() {
-			journal.AddEntry(date.MustAutoParse("1994-08-04"), "One")
-			journal.AddEntry(date.MustAutoParse("1994-08-20"), "Two")
-			journal.AddEntry(date.MustAutoParse("1994-08-25"), "Three")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-04"), "One")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "Two")
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-25"), "Three")
 
-			entry, e := journal.GetClosestEntry(date.MustAutoParse("1994-08-01"))
+			entry, e := journal.GetClosestEntry(ctx, date.MustAutoParse("1994-08-01"))
 			Expect(e).NotTo(HaveOccurred())
 			Expect(entry.EntryDate).To(Equal(date.MustAutoParse("1994-08-04")))
 			Expect(entry.EntryText).To(Equal("One"))
 
-			entry, e = journal.GetClosestEntry(date.MustAutoParse("1994-08-18"))
+			entry, e = journal.GetClosestEntry(ctx, date.MustAutoParse("1994-08-18"))
 			Expect(e).NotTo(HaveOccurred())
 			Expect(entry.EntryDate).To(Equal(date.MustAutoParse("1994-08-20")))
 			Expect(entry.EntryText).To(Equal("Two"))
 
-			entry, e = journal.GetClosestEntry(date.MustAutoParse("1994-08-25"))
+			entry, e = journal.GetClosestEntry(ctx, date.MustAutoParse("1994-08-25"))
 			Expect(e).NotTo(HaveOccurred())
 			Expect(entry.EntryDate).To(Equal(date.MustAutoParse("1994-08-25")))
 			Expect(entry.EntryText).To(Equal("Three"))
 
-			entry, e = journal.GetClosestEntry(date.MustAutoParse("1994-08-27"))
+			entry, e = journal.GetClosestEntry(ctx, date.MustAutoParse("1994-08-27"))
 			Expect(e).NotTo(HaveOccurred())
 			Expect(entry.EntryDate).To(Equal(date.MustAutoParse("1994-08-25")))
 			Expect(entry.EntryText).To(Equal("Three"))
 		})
 	})
+	Describe("List entries", func() {
+		It("stores the items of a list entry and makes them searchable as text", func() {
+			id, e := journal.AddListEntry(ctx, date.MustAutoParse("2019-03-01"), []string{"sunshine", "a good book, finally", "dinner with friends"})
+			Expect(e).NotTo(HaveOccurred())
+
+			entry, e := journal.GetEntryByID(ctx, id)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.Items).To(Equal([]string{"sunshine", "a good book, finally", "dinner with friends"}))
+			Expect(entry.EntryText).To(Equal("sunshine. a good book, finally. dinner with friends"))
+		})
+
+		It("treats entries without items as free text entries", func() {
+			id, e := journal.AddEntry(ctx, date.MustAutoParse("2019-03-01"), "one")
+			Expect(e).NotTo(HaveOccurred())
+
+			entry, e := journal.GetEntryByID(ctx, id)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.Items).To(BeEmpty())
+		})
+
+		It("keeps the items when the entry gets tagged", func() {
+			id, e := journal.AddListEntry(ctx, date.MustAutoParse("2019-03-01"), []string{"one", "two", "three"})
+			Expect(e).NotTo(HaveOccurred())
+			Expect(journal.TagEntry(ctx, id, "gratitude")).To(Succeed())
+
+			entry, e := journal.GetEntryByID(ctx, id)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.Items).To(Equal([]string{"one", "two", "three"}))
+		})
+	})
+
+	Describe("Moods", func() {
+		BeforeEach(func() {
+			for _, entry := range []struct {
+				date string
+				mood int
+			}{{"2019-03-01", 4}, {"2019-03-02", 9}, {"2019-03-03", 0}, {"2019-03-04", 9}, {"2019-04-01", 10}} {
+				id, e := journal.AddEntry(ctx, date.MustAutoParse(entry.date), "text")
+				Expect(e).NotTo(HaveOccurred())
+				if entry.mood != 0 {
+					Expect(journal.SetMood(ctx, id, entry.mood)).To(Succeed())
+				}
+			}
+		})
+
+		It("computes the average of rated entries only", func() {
+			summary, e := journal.AverageMood(ctx, "2019-03")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(summary.Count).To(Equal(3))
+			Expect(summary.Average).To(BeNumerically("~", 22.0/3))
+		})
+
+		It("finds all happiest entries", func() {
+			entries, e := journal.HappiestEntries(ctx, "2019-03")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("2019-03-02")))
+			Expect(entries[1].EntryDate).To(Equal(date.MustAutoParse("2019-03-04")))
+			Expect(entries[0].Mood).To(Equal(9))
+		})
+
+		It("rejects moods out of range", func() {
+			id, e := journal.AddEntry(ctx, date.MustAutoParse("2019-05-01"), "text")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(journal.SetMood(ctx, id, 11)).NotTo(Succeed())
+		})
+	})
+	Describe("GetEntriesOnDayOfYear", func() {
+		It("finds entries of previous years, oldest first", func() {
+			journal.AddEntry(ctx, date.MustAutoParse("2020-03-05"), "2020")
+			journal.AddEntry(ctx, date.MustAutoParse("2018-03-05"), "2018 first")
+			journal.AddEntry(ctx, date.MustAutoParse("2018-03-06"), "other day")
+			journal.AddEntry(ctx, date.MustAutoParse("2018-03-05"), "2018 second")
+			journal.AddEntry(ctx, date.MustAutoParse("2021-03-05"), "this year")
+
+			entries, e := journal.GetEntriesOnDayOfYear(ctx, time.March, 5, 2021)
+			Expect(e).NotTo(HaveOccurred())
+			var texts []string
+			for _, entry := range entries {
+				texts = append(texts, entry.EntryText)
+			}
+			Expect(texts).To(Equal([]string{"2018 first", "2018 second", "2020"}))
+		})
+	})
+	Describe("GetEntriesWithTag", func() {
+		It("filters by time range and tag", func() {
+			id, _ := journal.AddEntry(ctx, date.MustAutoParse("2019-03-05"), "one")
+			Expect(journal.TagEntry(ctx, id, "Holiday")).To(Succeed())
+			id, _ = journal.AddEntry(ctx, date.MustAutoParse("2020-03-05"), "two")
+			Expect(journal.TagEntry(ctx, id, "holiday")).To(Succeed())
+			journal.AddEntry(ctx, date.MustAutoParse("2020-03-06"), "three")
+
+			entries, e := journal.GetEntriesWithTag(ctx, "", "holiday")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+
+			entries, e = journal.GetEntriesWithTag(ctx, "2020", "holiday")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].EntryText).To(Equal("two"))
+
+			entries, e = journal.GetEntriesWithTag(ctx, "2020", "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+		})
+	})
+
+	Describe("Malformed rows", func() {
+		BeforeEach(func() {
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+			journal.Data.AppendRow(ctx, []string{"", "", "empty date"})
+			journal.Data.AppendRow(ctx, []string{"", "not a date", "invalid date"})
+			journal.Data.AppendRow(ctx, []string{"too few columns"})
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-21"), "two")
+		})
+
+		It("skips them and reports them", func() {
+			entries, e := journal.GetEntries(ctx, "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].EntryText).To(Equal("one"))
+			Expect(entries[1].EntryText).To(Equal("two"))
+
+			report := journal.ParseReport()
+			Expect(report.Errors).To(HaveLen(3))
+			Expect(report.Errors[0].Row).To(Equal(2))
+			Expect(report.Errors[0].Kind).To(Equal(j.EmptyDate))
+			Expect(report.Errors[1].Kind).To(Equal(j.InvalidDate))
+			Expect(report.Errors[2].Kind).To(Equal(j.TooFewColumns))
+			Expect(j.IsMalformedRowsError(report.Err())).To(BeTrue())
+		})
+
+		It("doesn't let them break search, statistics and lookups", func() {
+			journal.Index = custom.NewSearchIndex(zap.NewNop().Sugar())
+
+			entries, e := journal.SearchFor(ctx, "two")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].EntryDate).To(Equal(date.MustAutoParse("1994-08-21")))
+
+			stats, e := journal.Statistics(ctx, "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(stats.NumEntries).To(Equal(2))
+
+			entry, e := journal.GetClosestEntry(ctx, date.MustAutoParse("1994-08-22"))
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entry.EntryText).To(Equal("two"))
+		})
+
+		It("has an empty report when all rows can be read", func() {
+			journal = j.Journal{Data: &tsv.StringBasedTabularData{}}
+			journal.AddEntry(ctx, date.MustAutoParse("1994-08-20"), "one")
+
+			_, e := journal.GetEntries(ctx, "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(journal.ParseReport().Err()).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MinMood = 1
+	MaxMood = 10
+)
+
+type MoodSummary struct {
+	Average float64
+	// Count is the number of rated entries the average is based on.
+	Count int
+}
+
+func moodFrom(s string) int {
+	mood, e := strconv.Atoi(s)
+	if e != nil || mood < MinMood || mood > MaxMood {
+		return 0
+	}
+	return mood
+}
+
+// SetMood stores the user's rating of the day for the entry with the given id.
+func (j *Journal) SetMood(ctx context.Context, id string, mood int) error {
+	if mood < MinMood || mood > MaxMood {
+		return errors.Errorf("Mood must be between %v and %v. Given: %v", MinMood, MaxMood, mood)
+	}
+	return j.updateEntry(ctx, id, func(entry *Entry) { entry.Mood = mood })
+}
+
+// AverageMood returns the average mood of all rated entries in timeRange, which is
+// a date prefix such as "2019" or "2019-03".
+func (j *Journal) AverageMood(ctx context.Context, timeRange string) (MoodSummary, error) {
+	entries, e := j.GetEntries(ctx, timeRange)
+	if e != nil {
+		return MoodSummary{}, errors.Wrap(e, "Could not get average mood")
+	}
+	var summary MoodSummary
+	sum := 0
+	for _, entry := range entries {
+		if entry.Mood != 0 {
+			sum += entry.Mood
+			summary.Count++
+		}
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(sum) / float64(summary.Count)
+	}
+	return summary, nil
+}
+
+// HappiestEntries returns all entries in timeRange that have the highest mood, ordered by date.
+func (j *Journal) HappiestEntries(ctx context.Context, timeRange string) ([]Entry, error) {
+	entries, e := j.GetEntries(ctx, timeRange)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not get happiest entries")
+	}
+	var result []Entry
+	for _, entry := range entries {
+		if entry.Mood == 0 {
+			continue
+		}
+		if len(result) > 0 && entry.Mood < result[0].Mood {
+			continue
+		}
+		if len(result) > 0 && entry.Mood > result[0].Mood {
+			result = nil
+		}
+		result = append(result, entry)
+	}
+	sort.SliceStable(result, ByEntryDate(result))
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package journal
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
+)
+
+type Statistics struct {
+	NumEntries int
+	// NumDays is the number of distinct days with at least one entry.
+	NumDays        int
+	FirstEntryDate date.Date
+	LastEntryDate  date.Date
+	LongestStreak  Streak
+}
+
+// Streak is a run of consecutive days with at least one entry each.
+type Streak struct {
+	Start  date.Date
+	Length int
+}
+
+func (s Streak) End() date.Date {
+	if s.Length == 0 {
+		return s.Start
+	}
+	return s.Start.Add(date.PeriodOfDays(s.Length - 1))
+}
+
+// Statistics computes statistics about all entries in timeRange, which is a date prefix such as
+// "2019" or "2019-03". An empty timeRange covers the whole journal.
+func (j *Journal) Statistics(ctx context.Context, timeRange string) (Statistics, error) {
+	entries, e := j.GetEntries(ctx, timeRange)
+	if e != nil {
+		return Statistics{}, errors.Wrap(e, "Could not compute statistics")
+	}
+	var stats Statistics
+	days := make(map[date.Date]bool)
+	for _, entry := range entries {
+		d := entry.EntryDate
+		stats.NumEntries++
+		days[d] = true
+		if stats.FirstEntryDate.IsZero() || d.Before(stats.FirstEntryDate) {
+			stats.FirstEntryDate = d
+		}
+		if stats.LastEntryDate.IsZero() || d.After(stats.LastEntryDate) {
+			stats.LastEntryDate = d
+		}
+	}
+	stats.NumDays = len(days)
+	stats.LongestStreak = longestStreakIn(days)
+	return stats, nil
+}
+
+func longestStreakIn(days map[date.Date]bool) Streak {
+	var longest Streak
+	for d := range days {
+		if days[d.Add(-1)] {
+			// not the start of a streak
+			continue
+		}
+		streak := Streak{Start: d}
+		for days[d.Add(date.PeriodOfDays(streak.Length))] {
+			streak.Length++
+		}
+		if streak.Length > longest.Length || (streak.Length == longest.Length && streak.Start.Before(longest.Start)) {
+			longest = streak
+		}
+	}
+	return longest
+}
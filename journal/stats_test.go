@@ -0,0 +1,49 @@
+package journal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/tsv"
+	"github.com/rickb777/date"
+)
+
+var _ = Describe("Statistics", func() {
+	var journal j.Journal
+
+	BeforeEach(func() {
+		journal = j.Journal{Data: &tsv.StringBasedTabularData{}}
+		for _, d := range []string{"2018-12-30", "2019-01-02", "2019-01-03", "2019-01-03", "2019-01-04", "2019-02-10", "2019-02-11"} {
+			_, e := journal.AddEntry(ctx, date.MustAutoParse(d), "text")
+			Expect(e).NotTo(HaveOccurred())
+		}
+	})
+
+	It("computes statistics for the whole journal", func() {
+		stats, e := journal.Statistics(ctx, "")
+		Expect(e).NotTo(HaveOccurred())
+		Expect(stats.NumEntries).To(Equal(7))
+		Expect(stats.NumDays).To(Equal(6))
+		Expect(stats.FirstEntryDate).To(Equal(date.MustAutoParse("2018-12-30")))
+		Expect(stats.LastEntryDate).To(Equal(date.MustAutoParse("2019-02-11")))
+		Expect(stats.LongestStreak.Length).To(Equal(3))
+		Expect(stats.LongestStreak.Start).To(Equal(date.MustAutoParse("2019-01-02")))
+		Expect(stats.LongestStreak.End()).To(Equal(date.MustAutoParse("2019-01-04")))
+	})
+
+	It("computes statistics for a time range", func() {
+		stats, e := journal.Statistics(ctx, "2019-02")
+		Expect(e).NotTo(HaveOccurred())
+		Expect(stats.NumEntries).To(Equal(2))
+		Expect(stats.NumDays).To(Equal(2))
+		Expect(stats.FirstEntryDate).To(Equal(date.MustAutoParse("2019-02-10")))
+		Expect(stats.LongestStreak.Length).To(Equal(2))
+	})
+
+	It("returns zero values for an empty journal", func() {
+		emptyJournal := j.Journal{Data: &tsv.StringBasedTabularData{}}
+		stats, e := emptyJournal.Statistics(ctx, "")
+		Expect(e).NotTo(HaveOccurred())
+		Expect(stats).To(Equal(j.Statistics{}))
+	})
+})
@@ -0,0 +1,342 @@
+package journalskill
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/petergtz/alexa-journal/locale"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+)
+
+// mainJournalID is the slot value ID of the user's main journal, i.e. the one they had before creating others.
+const mainJournalID = "MAIN"
+
+// mergeBatchSize is the number of rows appended at once when merging journals.
+const mergeBatchSize = 500
+
+// spreadsheetNameFor returns the name of the spreadsheet that holds the user's active journal.
+func spreadsheetNameFor(config Config, l Localizer) string {
+	if config.ActiveJournal == "" {
+		return l.Get(r.Journal)
+	}
+	return l.GetTemplated(r.NamedJournalSpreadsheet, map[string]interface{}{"Name": config.ActiveJournal})
+}
+
+// journalLocales are the locales the names of spreadsheets are looked up in, one per language of the skill.
+var journalLocales = []string{"de-DE", "en-US"}
+
+// journalLocationFor tells where the user's active journal is. Its names in the other languages are alternatives,
+// so that users who switch the language of their device keep their journal.
+func (h *JournalSkill) journalLocationFor(config Config, l Localizer) JournalLocation {
+	location := JournalLocation{
+		SpreadsheetName: spreadsheetNameFor(config, l),
+		SpreadsheetID:   config.SpreadsheetIDs[journalKey(config.ActiveJournal)],
+		FolderID:        config.FolderID,
+	}
+	for _, journalLocale := range journalLocales {
+		name := spreadsheetNameFor(config, locale.NewLocalizer(h.i18nBundle, journalLocale, false))
+		if name != location.SpreadsheetName {
+			location.AlternativeNames = append(location.AlternativeNames, name)
+		}
+	}
+	return location
+}
+
+// withSpreadsheetID returns a copy of config in which the active journal is in the spreadsheet with id. An empty id
+// forgets the active journal's spreadsheet, so that it's looked up by name again.
+func withSpreadsheetID(config Config, id string) Config {
+	newConfig := config
+	newConfig.SpreadsheetIDs = make(map[string]string)
+	for key, id := range config.SpreadsheetIDs {
+		newConfig.SpreadsheetIDs[key] = id
+	}
+	if id == "" {
+		delete(newConfig.SpreadsheetIDs, journalKey(config.ActiveJournal))
+	} else {
+		newConfig.SpreadsheetIDs[journalKey(config.ActiveJournal)] = id
+	}
+	return newConfig
+}
+
+// journalKey returns the key of the journal with the given name in Config.SpreadsheetIDs.
+func journalKey(name string) string {
+	if name == "" {
+		return mainJournalID
+	}
+	return name
+}
+
+// journalNameFrom returns the journal name the user said, where the main journal is the empty name. It returns
+// false if the user didn't say a name.
+func journalNameFrom(slot alexa.IntentSlot) (string, bool) {
+	if resolvedValueID(slot) == mainJournalID {
+		return "", true
+	}
+	name := strings.ToLower(strings.TrimSpace(slot.Value))
+	return name, name != ""
+}
+
+func hasJournal(config Config, name string) bool {
+	for _, journal := range config.Journals {
+		if journal == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *JournalSkill) listJournals(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if len(in.Config.Journals) == 0 {
+		return in.Response().Speak(l.Get(r.OnlyMainJournal, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	text := l.GetTemplated(r.YourJournals, map[string]interface{}{"Names": strings.Join(in.Config.Journals, ", ")})
+	if in.Config.ActiveJournal == "" {
+		text += " " + l.Get(r.ActiveJournalIsMain)
+	} else {
+		text += " " + l.GetTemplated(r.ActiveJournalIs, map[string]interface{}{"Name": in.Config.ActiveJournal})
+	}
+	return in.Response().Speak(text + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+func (h *JournalSkill) createJournal(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	name, ok := journalNameFrom(in.RequestEnv.Request.Intent.Slots["journalName"])
+	if !ok || name == "" {
+		return in.Response().Speak(l.Get(r.MissingJournalName)).Build()
+	}
+	newConfig := in.Config
+	newConfig.ActiveJournal = name
+	if hasJournal(in.Config, name) {
+		h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+		return in.Response().
+			Speak(l.GetTemplated(r.JournalAlreadyExists, map[string]interface{}{"Name": name}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	newConfig.Journals = append(append([]string{}, in.Config.Journals...), name)
+	// Getting the journal creates its spreadsheet, so that problems with it show up right away. Only then the
+	// journal is remembered, so that the user isn't left with a journal that doesn't exist.
+	journal, e := h.journalProvider.Get(in.Ctx, in.accessToken(), h.journalLocationFor(newConfig, l))
+	if e != nil {
+		return h.journalErrorResponse(in, l.Get(r.CreateJournalError, r.ShortPause), e)
+	}
+	if journal.StorageID != "" {
+		newConfig = withSpreadsheetID(newConfig, journal.StorageID)
+	}
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayJournalCreated, map[string]interface{}{"Name": name}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) switchJournal(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	name, ok := journalNameFrom(in.RequestEnv.Request.Intent.Slots["journalName"])
+	if !ok {
+		return in.Response().Speak(l.Get(r.MissingJournalName)).Build()
+	}
+	if name != "" && !hasJournal(in.Config, name) {
+		return in.Response().
+			Speak(l.GetTemplated(r.UnknownJournal, map[string]interface{}{"Name": name}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	newConfig := in.Config
+	newConfig.ActiveJournal = name
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	if name == "" {
+		return in.Response().Speak(l.Get(r.OkayMainJournalOpen, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayJournalOpen, map[string]interface{}{"Name": name}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+// journalErrorResponse asks the user to choose a spreadsheet when there are several the active journal could be in,
+// or to merge them when the journal's entries are spread across them. Otherwise, it works like errorResponse.
+func (h *JournalSkill) journalErrorResponse(in *Input, text string, e error) *alexa.ResponseEnvelope {
+	if split, ok := errors.Cause(e).(SplitJournalError); ok {
+		return h.askToMergeJournalFiles(in, split.Parts())
+	}
+	ambiguous, ok := errors.Cause(e).(AmbiguousJournalError)
+	if !ok {
+		return h.errorResponse(in, text, e)
+	}
+	l := in.Localizer
+	in.Session.JournalFileCandidates = nil
+	var candidates []string
+	for i, file := range ambiguous.Candidates() {
+		in.Session.JournalFileCandidates = append(in.Session.JournalFileCandidates, file.ID)
+		candidates = append(candidates, l.GetTemplated(r.JournalFileCandidate, map[string]interface{}{
+			"Number": i + 1,
+			"Date": l.GetTemplated(r.CandidateDate, map[string]interface{}{
+				"Day":   file.ModifiedTime.Day(),
+				"Month": l.Month(int(file.ModifiedTime.Month())),
+				"Year":  file.ModifiedTime.Year(),
+			}),
+		}))
+	}
+	question := l.GetTemplated(r.MultipleJournalFilesFound, map[string]interface{}{
+		"Count":      len(candidates),
+		"Name":       spreadsheetNameFor(in.Config, l),
+		"Candidates": strings.Join(candidates, ". "),
+	})
+	return in.ResponseWithSession().
+		Speak(question).
+		Reprompt(l.Get(r.WhichJournalFile)).
+		Build()
+}
+
+func (h *JournalSkill) chooseJournalFile(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	candidates := in.Session.JournalFileCandidates
+	if len(candidates) == 0 {
+		return in.Response().Speak(l.Get(r.NoJournalFileToChoose, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	number, e := strconv.Atoi(in.RequestEnv.Request.Intent.Slots["number"].Value)
+	if e != nil || number < 1 || number > len(candidates) {
+		return in.Response().
+			Speak(l.GetTemplated(r.InvalidJournalFileNumber, map[string]interface{}{"Count": len(candidates)})).
+			Reprompt(l.Get(r.WhichJournalFile)).
+			Build()
+	}
+	h.configService.PersistConfig(in.Ctx, in.userID(), withSpreadsheetID(in.Config, candidates[number-1]))
+	in.Session.JournalFileCandidates = nil
+	return in.ResponseWithSession().
+		Speak(l.GetTemplated(r.OkayJournalFileChosen, map[string]interface{}{"Number": number}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) useJournalFolder(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	folderName := strings.TrimSpace(in.RequestEnv.Request.Intent.Slots["folderName"].Value)
+	if folderName == "" {
+		return in.Response().Speak(l.Get(r.MissingFolderName)).Build()
+	}
+	folderID, e := h.journalProvider.FolderID(in.Ctx, in.accessToken(), folderName)
+	if e != nil {
+		return h.errorResponse(in, "", e)
+	}
+	if folderID == "" {
+		return in.Response().
+			Speak(l.GetTemplated(r.FolderNotFound, map[string]interface{}{"Folder": folderName}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	newConfig := in.Config
+	newConfig.FolderID = folderID
+	newConfig.FolderName = folderName
+	// Spreadsheets remembered before stay in use, wherever they are, so that no journal gets lost. Only journals
+	// without one are looked for in the folder.
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayFolderChosen, map[string]interface{}{"Folder": folderName}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) askToMergeJournalFiles(in *Input, parts []JournalFile) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	in.Session.JournalFilesToMerge = nil
+	in.Session.MergeTargetName = parts[0].Name
+	var others []string
+	for i, part := range parts {
+		in.Session.JournalFilesToMerge = append(in.Session.JournalFilesToMerge, part.ID)
+		if i > 0 {
+			others = append(others, part.Name)
+		}
+	}
+	return in.ResponseWithSession().
+		Speak(l.GetTemplated(r.JournalSplitFound, map[string]interface{}{
+			"Name":   parts[0].Name,
+			"Others": strings.Join(others, ", "),
+		})).
+		Reprompt(l.Get(r.ShouldIMergeJournals)).
+		Build()
+}
+
+// mergeJournalFiles copies the entries of all spreadsheets the user was asked to merge into the first one, which is
+// used for the active journal from then on. The other spreadsheets are left untouched. Large journals may not be
+// merged within the time of a request. Since entries that are already in the first spreadsheet aren't copied again,
+// the user is then asked to simply try again, which continues where the merge stopped.
+func (h *JournalSkill) mergeJournalFiles(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	ids := in.Session.JournalFilesToMerge
+	if len(ids) == 0 {
+		return in.Response().Speak(l.Get(r.NothingToConfirm, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	location := h.journalLocationFor(in.Config, l)
+	location.SpreadsheetID = ids[0]
+	target, e := h.journalProvider.Get(in.Ctx, in.accessToken(), location)
+	if e != nil {
+		return h.mergeErrorResponse(in, e)
+	}
+	for _, id := range ids[1:] {
+		location.SpreadsheetID = id
+		source, e := h.journalProvider.Get(in.Ctx, in.accessToken(), location)
+		if e != nil {
+			return h.mergeErrorResponse(in, e)
+		}
+		entries, e := source.GetEntries(in.Ctx, "")
+		if e != nil {
+			return h.mergeErrorResponse(in, e)
+		}
+		result, e := target.ImportEntries(in.Ctx, entries, mergeBatchSize)
+		if e != nil {
+			return h.mergeErrorResponse(in, e)
+		}
+		in.Log.Infow("Merged journal", "imported", result.Imported, "duplicates", result.Duplicates)
+	}
+	h.configService.PersistConfig(in.Ctx, in.userID(), withSpreadsheetID(in.Config, ids[0]))
+	name := in.Session.MergeTargetName
+	in.Session.JournalFilesToMerge = nil
+	in.Session.MergeTargetName = ""
+	return in.ResponseWithSession().
+		Speak(l.GetTemplated(r.OkayJournalsMerged, map[string]interface{}{"Name": name}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+// mergeErrorResponse tells the user that merging failed and asks whether to try again. If one of the spreadsheets
+// is gone, trying again can't help. The merge is then given up, and the journal is looked up by name again.
+func (h *JournalSkill) mergeErrorResponse(in *Input, e error) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if h.errorInterpreter.RequiresAccountLinking(e) {
+		return h.errorResponse(in, l.Get(r.MergeJournalsError, r.ShortPause), e)
+	}
+	if h.errorInterpreter.IsNotFound(e) {
+		in.Session.JournalFilesToMerge = nil
+		in.Session.MergeTargetName = ""
+		return in.ResponseWithSession().
+			Speak(l.Get(r.MergeJournalsError, r.ShortPause) + h.errorInterpreter.Interpret(in.Ctx, e, l)).
+			Build()
+	}
+	return in.ResponseWithSession().
+		Speak(l.Get(r.MergeJournalsError, r.ShortPause) + h.errorInterpreter.Interpret(in.Ctx, e, l) +
+			l.Get(r.ShortPause, r.MergeJournalsRetry)).
+		Reprompt(l.Get(r.ShouldIMergeJournals)).
+		Build()
+}
+
+// keepJournalFilesSeparate uses the spreadsheet the user was asked to merge the others into for the active journal.
+func (h *JournalSkill) keepJournalFilesSeparate(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	ids := in.Session.JournalFilesToMerge
+	if len(ids) == 0 {
+		return in.Response().Speak(l.Get(r.NothingToConfirm, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	h.configService.PersistConfig(in.Ctx, in.userID(), withSpreadsheetID(in.Config, ids[0]))
+	name := in.Session.MergeTargetName
+	in.Session.JournalFilesToMerge = nil
+	in.Session.MergeTargetName = ""
+	return in.ResponseWithSession().
+		Speak(l.GetTemplated(r.OkayJournalsNotMerged, map[string]interface{}{"Name": name}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
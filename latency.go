@@ -0,0 +1,31 @@
+package journalskill
+
+import (
+	"time"
+
+	"github.com/petergtz/alexa-journal/metrics"
+	alexa "github.com/petergtz/go-alexa"
+)
+
+// LatencyMetrics records how long each request took per intent and whether it succeeded. It must be used as request
+// interceptor and as response interceptor: the request interceptor makes sure the request is recorded once it's done,
+// the response interceptor marks it as successful. Requests that panicked don't get to the response interceptors, so
+// they are recorded as errors.
+type LatencyMetrics struct {
+	Metrics metrics.Metrics
+}
+
+func (lm *LatencyMetrics) Intercept(in *Input) *alexa.ResponseEnvelope {
+	in.Defer(func() {
+		outcome := metrics.OutcomeError
+		if in.succeeded {
+			outcome = metrics.OutcomeSuccess
+		}
+		lm.Metrics.Duration("request", time.Since(in.Start), metrics.Dimensions{"intent": in.intentName(), "outcome": outcome})
+	})
+	return nil
+}
+
+func (lm *LatencyMetrics) InterceptResponse(in *Input, responseEnv *alexa.ResponseEnvelope) {
+	in.succeeded = true
+}
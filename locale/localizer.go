@@ -2,6 +2,7 @@ package locale
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,6 +51,24 @@ func (l *Localizer) Month(m int) string {
 	return resources.Months[l.lang][m]
 }
 
+func (l *Localizer) Decimal(f float64) string {
+	return strings.Replace(strconv.FormatFloat(f, 'f', 1, 64), ".", resources.DecimalSeparators[l.lang], 1)
+}
+
+// Prompt returns the n-th prompt of the given prompt set, wrapping around at the end of the set.
+// It returns an empty string if the prompt set doesn't exist.
+func (l *Localizer) Prompt(promptSet string, n int) string {
+	prompts := resources.Prompts[l.lang][promptSet]
+	if len(prompts) == 0 {
+		return ""
+	}
+	return prompts[n%len(prompts)]
+}
+
+func (l *Localizer) PromptSetName(promptSet string) string {
+	return resources.PromptSetNames[l.lang][promptSet]
+}
+
 func (l *Localizer) mustLocalize(lc *i18n.LocalizeConfig) string {
 	if l.shouldBeSuccinct {
 		suffixed := *lc
@@ -32,6 +32,12 @@ var _ = Describe("Localizer", func() {
 						l.Get(resources.StringID(i))
 					}
 				})
+				It("has a name and prompts for every prompt set", func() {
+					for _, promptSet := range []string{r.PromptSetGratitude, r.PromptSetReflection, r.PromptSetWorkLog} {
+						Expect(l.PromptSetName(promptSet)).NotTo(BeEmpty())
+						Expect(l.Prompt(promptSet, 0)).NotTo(BeEmpty())
+					}
+				})
 			})
 		}(lang)
 	}
@@ -72,4 +78,19 @@ var _ = Describe("Localizer", func() {
 			})
 		})
 	})
+
+	Context("Prompts", func() {
+		BeforeEach(func() { l = locale.NewLocalizer(i18nBundle, "en-US", false) })
+
+		It("rotates through the prompts of a prompt set", func() {
+			numPrompts := len(resources.Prompts["en-US"][r.PromptSetGratitude])
+			Expect(l.Prompt(r.PromptSetGratitude, 0)).To(Equal("What are you grateful for today?"))
+			Expect(l.Prompt(r.PromptSetGratitude, 1)).To(Equal("Who made your day a little better today?"))
+			Expect(l.Prompt(r.PromptSetGratitude, numPrompts)).To(Equal("What are you grateful for today?"))
+		})
+
+		It("returns an empty prompt for unknown prompt sets", func() {
+			Expect(l.Prompt("UNKNOWN", 0)).To(BeEmpty())
+		})
+	})
 })
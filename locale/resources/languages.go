@@ -94,7 +94,104 @@ var DeDe = []byte(tomlStringFrom(map[StringID]string{
 	DriveMultipleFilesFoundError: `Ich habe in Deinem Google Drive mehr als eine Datei mit dem Namen Tagebuch gefunden. Bitte Stelle sicher, dass es nur eine Datei mit diesem Namen gibt.`,
 	DriveSheetNotFoundError:      "Ich habe in Deinem Spreadsheet kein Tabellenblatt mit dem Namen Tagebuch gefunden. Bitte stelle sicher, dass dies existiert.",
 	DriveUnknownError:            "Es gab einen Fehler. Genauere Details kann ich aktuell leider nicht herausfinden. Ich habe den Entwickler bereits informiert, er wird sich um das Problem kümmern. Bitte versuche es später noch einmal.",
+	DriveAuthExpiredError:        `Die Verbindung zu Deinem Google Account ist abgelaufen. Bitte verbinde Alexa in der Alexa App erneut mit Deinem Google Account.`,
+	DrivePermissionDeniedError:   `Ich habe keine Berechtigung mehr, auf Dein Tagebuch in Google Drive zuzugreifen. Bitte verbinde Alexa in der Alexa App erneut mit Deinem Google Account.`,
+	DriveRateLimitedError:        `Google Drive bekommt gerade zu viele Anfragen von mir. Bitte versuche es in ein paar Minuten noch einmal.`,
+	DriveUnavailableError:        `Google Drive ist gerade nicht erreichbar. Bitte versuche es später noch einmal.`,
 	Journal:                      "Tagebuch",
+	EntryNotFoundError:           "Ich konnte den Eintrag nicht mehr finden. Vielleicht wurde er in der Zwischenzeit geändert oder gelöscht.",
+	NewEntrySaveError:            "Oje. Beim Speichern des Eintrags ist ein Fehler aufgetreten.",
+	HowWasYourDay:                `Wie war Dein Tag auf einer Skala von eins bis zehn?`,
+	MoodSaved:                    `Danke. Ich habe {{.Mood}} von zehn notiert.`,
+	InvalidMood:                  `Bitte nenne eine Zahl von eins bis zehn.`,
+	NoEntryToRate:                `Du kannst Deinen Tag bewerten, nachdem Du einen neuen Eintrag gespeichert hast.`,
+	MoodSaveError:                `Oje. Beim Speichern Deiner Stimmung ist ein Fehler aufgetreten.`,
+	AverageMood:                  `Deine durchschnittliche Stimmung im Zeitraum {{.TimeRange}} war {{.Average}} von zehn. Das basiert auf {{.Count}} bewerteten Einträgen.`,
+	NoMoodsInTimeRange:           `Für den Zeitraum {{.TimeRange}} habe ich keine Bewertungen Deiner Stimmung gefunden.`,
+	HappiestDays:                 `Im Zeitraum {{.TimeRange}} warst Du am glücklichsten am {{.Dates}}, mit {{.Mood}} von zehn.`,
+	CouldNotGetMoods:             `Oje. Beim Abrufen Deiner Stimmung ist ein Fehler aufgetreten.`,
+	InTimeRange:                  `im Zeitraum {{.TimeRange}}`,
+	InTotal:                      `insgesamt`,
+	StatisticsEntryCount:         `Du hast {{.InTimeRange}} {{.Count}} Einträge geschrieben.`,
+	StatisticsDaysWritten:        `Du hast {{.InTimeRange}} an {{.Count}} Tagen geschrieben.`,
+	StatisticsLongestStreak:      `Deine längste Serie {{.InTimeRange}} war {{.Length}} Tage am Stück, vom {{.Start}} bis zum {{.End}}.`,
+	StatisticsFirstEntry:         `Dein erster Eintrag {{.InTimeRange}} ist vom {{.WeekDay}}, {{.Date}}.`,
+	CouldNotGetStatistics:        `Oje. Beim Auswerten Deines Tagebuchs ist ein Fehler aufgetreten.`,
+
+	YourJournalIsNowOpenWithoutQuestion: `Dein Tagebuch ist nun geöffnet.`,
+	OnThisDayIntro:                      `An diesem Tag in früheren Jahren:`,
+	OnThisDayYear:                       `Im Jahr {{.Year}}: {{.Text}}.`,
+	NoEntriesOnThisDay:                  `An diesem Tag hast Du in früheren Jahren noch nichts geschrieben.`,
+	OkayOnThisDayGreetingEnabled:        `Okay. Wenn Du Dein Tagebuch öffnest, lese ich Dir ab jetzt vor, was an diesem Tag in früheren Jahren war.`,
+	OkayOnThisDayGreetingDisabled:       `Okay. Wenn Du Dein Tagebuch öffnest, lese ich Dir nicht mehr vor, was an diesem Tag in früheren Jahren war.`,
+	OkayMoodQuestionEnabled:             `Okay. Ab jetzt frage ich Dich nach jedem Eintrag, wie Dein Tag war.`,
+	OkayMoodQuestionDisabled:            `Okay. Ich frage Dich nach einem Eintrag nicht mehr, wie Dein Tag war. Du kannst ihn aber weiterhin bewerten, indem Du z.B. \"bewerte meinen Tag mit 7\" sagst.`,
+	NoMemoriesFound:                     `Ich habe keine passende Erinnerung gefunden.`,
+	NoMemoriesWithTagFound:              `Ich habe keine Erinnerung mit dem Schlagwort \"{{.Tag}}\" gefunden.`,
+
+	ReminderText:               `Zeit für Deinen Tagebucheintrag.`,
+	OkayReminderSet:            `Okay. Ich erinnere Dich ab jetzt jeden Tag um {{.Time}} Uhr daran, in Dein Tagebuch zu schreiben.`,
+	OkayReminderCancelled:      `Okay. Ich erinnere Dich nicht mehr daran, in Dein Tagebuch zu schreiben.`,
+	NoReminderToCancel:         `Du hast keine tägliche Erinnerung eingerichtet.`,
+	InvalidReminderTime:        `Entschuldige, diese Uhrzeit habe ich nicht verstanden.`,
+	RemindersPermissionMissing: `Damit ich Dich erinnern kann, gib mir bitte zuerst in der Alexa App die Berechtigung für Erinnerungen.`,
+	RemindersPermissionCard:    `Bitte erlaube dem Tagebuch in den Skill-Einstellungen der Alexa App, Erinnerungen zu erstellen.`,
+	ReminderError:              `Beim Einrichten der Erinnerung ist leider ein Fehler aufgetreten.`,
+
+	GuidedPrompt:        `Hier ist Deine Frage {{.ForDate}}: {{.Prompt}}`,
+	OkayPromptSetChosen: `Okay. Ab jetzt stelle ich Dir zu Beginn jedes neuen Eintrags eine Frage zum Thema {{.PromptSet}}.`,
+	OkayPromptsDisabled: `Okay. Ab jetzt stelle ich Dir zu Beginn eines neuen Eintrags keine Frage mehr.`,
+	UnknownPromptSet:    `Entschuldige, diese Fragen kenne ich nicht. Du kannst zwischen Dankbarkeit, Reflexion und Arbeitstagebuch wählen.`,
+
+	GratitudeListStart:                `Lass uns {{.Count}} schöne Dinge {{.ForDate}} festhalten. Ich wiederhole jedes davon, sodass Du es \"korrigieren\" oder \"anhören\" kannst.`,
+	GratitudeListStart_succinct:       `Lass uns {{.Count}} schöne Dinge {{.ForDate}} festhalten.`,
+	GratitudeListItemPrompt:           `Was ist schönes Ding Nummer {{.Number}}?`,
+	GratitudeListRepeatItem:           `Ich wiederhole: {{.Text}}.`,
+	GratitudeListEmptyNoRepeat:        `Du hast noch nichts genannt. Es gibt nichts zu wiederholen.`,
+	GratitudeListEmptyNoCorrect:       `Du hast noch nichts genannt. Es gibt nichts zu korrigieren.`,
+	GratitudeListOkayCorrect:          `OK. Bitte nenne Nummer {{.Number}} erneut.`,
+	GratitudeListConfirmation:         `Alles klar. Deine schönen Dinge für das Datum {{.Date}}: {{.Items}}. Soll ich sie so speichern?`,
+	GratitudeListConfirmationReprompt: `Soll ich Deine schönen Dinge so speichern?`,
+	ListItem:                          `Nummer {{.Number}}: {{.Item}}`,
+
+	OkayExported: `Okay. Ich habe Dein Tagebuch als {{.Filename}} in Deinem Google Drive gespeichert.`,
+	ExportError:  `Beim Exportieren Deines Tagebuchs ist ein Fehler aufgetreten.`,
+
+	SomeEntriesCouldNotBeRead: `Einige Einträge in Deinem Tagebuch konnten nicht gelesen werden und wurden übersprungen.`,
+	OpeningJournal:            `Einen Moment, ich öffne Dein Tagebuch.`,
+
+	NamedJournalSpreadsheet: `Tagebuch - {{.Name}}`,
+	OnlyMainJournal:         `Du hast nur Dein Haupttagebuch. Um ein weiteres anzulegen, sage z.B. \"lege das Tagebuch Träume an\".`,
+	YourJournals:            `Neben Deinem Haupttagebuch hast Du diese Tagebücher: {{.Names}}.`,
+	ActiveJournalIsMain:     `Geöffnet ist Dein Haupttagebuch.`,
+	ActiveJournalIs:         `Geöffnet ist Dein Tagebuch {{.Name}}.`,
+	OkayMainJournalOpen:     `Okay, Dein Haupttagebuch ist nun geöffnet.`,
+	OkayJournalOpen:         `Okay, Dein Tagebuch {{.Name}} ist nun geöffnet.`,
+	OkayJournalCreated:      `Okay, ich habe das Tagebuch {{.Name}} angelegt und geöffnet.`,
+	JournalAlreadyExists:    `Das Tagebuch {{.Name}} gibt es schon. Ich habe es geöffnet.`,
+	UnknownJournal:          `Ein Tagebuch {{.Name}} gibt es noch nicht. Um es anzulegen, sage \"lege das Tagebuch {{.Name}} an\".`,
+	MissingJournalName:      `Entschuldige, den Namen des Tagebuchs habe ich nicht verstanden.`,
+	CreateJournalError:      `Beim Anlegen des Tagebuchs ist ein Fehler aufgetreten.`,
+
+	MultipleJournalFilesFound: `Ich habe in Deinem Google Drive {{.Count}} Tabellen namens {{.Name}} gefunden. {{.Candidates}}. Welche soll ich für Dein Tagebuch nehmen? Sage z.B. \"Nummer 1\".`,
+	JournalFileCandidate:      `Nummer {{.Number}}, zuletzt geändert am {{.Date}}`,
+	CandidateDate:             `{{.Day}}. {{.Month}} {{.Year}}`,
+	WhichJournalFile:          `Welche Tabelle soll ich für Dein Tagebuch nehmen? Sage z.B. \"Nummer 1\".`,
+	NoJournalFileToChoose:     `Es gibt gerade nichts auszuwählen.`,
+	InvalidJournalFileNumber:  `Bitte nenne eine Nummer zwischen 1 und {{.Count}}.`,
+	OkayJournalFileChosen:     `Okay, ab jetzt nehme ich Nummer {{.Number}}.`,
+	MissingFolderName:         `Entschuldige, den Namen des Ordners habe ich nicht verstanden.`,
+	FolderNotFound:            `Einen Ordner namens {{.Folder}} habe ich in Deinem Google Drive nicht gefunden.`,
+	OkayFolderChosen:          `Okay, ab jetzt suche und lege ich Deine Tagebücher im Ordner {{.Folder}} an.`,
+	JournalSplitFound:         `Ich habe Einträge Deines Tagebuchs in mehreren Tabellen gefunden: {{.Name}} und {{.Others}}. Das passiert, wenn Du mich in verschiedenen Sprachen benutzt. Soll ich alle Einträge in {{.Name}} zusammenführen, damit Du nur noch ein Tagebuch hast?`,
+	ShouldIMergeJournals:      `Soll ich die Tabellen zu einem Tagebuch zusammenführen? Sage bitte ja oder nein.`,
+	OkayJournalsMerged:        `Erledigt. Alle Einträge sind jetzt in {{.Name}}. Die anderen Tabellen sind noch in Deinem Google Drive. Du kannst sie löschen, wenn Du sie nicht mehr brauchst.`,
+	OkayJournalsNotMerged:     `Okay, ich benutze ab jetzt nur noch {{.Name}}. Die Einträge in den anderen Tabellen bleiben, wo sie sind.`,
+	MergeJournalsError:        `Beim Zusammenführen Deiner Tagebücher ist ein Fehler aufgetreten.`,
+	MergeJournalsRetry:        `Einträge, die schon zusammengeführt sind, übernehme ich nicht doppelt. Soll ich es noch einmal versuchen?`,
+	NothingToConfirm:          `Es gibt gerade nichts zu bestätigen.`,
+	DriveSplitJournalError:    `Die Einträge Deines Tagebuchs sind auf mehrere Tabellen verteilt. Öffne Dein Tagebuch, um sie zusammenzuführen.`,
+	DriveJournalNotFoundError: `Ich kann die Tabelle Deines Tagebuchs in Deinem Google Drive nicht mehr finden. Vielleicht wurde sie gelöscht oder ist nicht mehr für Dich freigegeben. Bitte versuche es noch einmal, dann suche ich Dein Tagebuch über seinen Namen.`,
 }))
 
 var weekdaysEn = map[time.Weekday]string{
@@ -124,6 +221,15 @@ var Weekdays = map[string]map[time.Weekday]string{
 	"en-AU": weekdaysEn,
 }
 
+var DecimalSeparators = map[string]string{
+	"de-DE": ",",
+	"en-US": ".",
+	"en-GB": ".",
+	"en-IN": ".",
+	"en-CA": ".",
+	"en-AU": ".",
+}
+
 var monthsEn = map[int]string{
 	1:  "january",
 	2:  "february",
@@ -249,7 +355,104 @@ var EnUs = []byte(tomlStringFrom(map[StringID]string{
 	DriveMultipleFilesFoundError: `I found more than one file with the name Journal in your Google Drive. Please make sure that there is only one file with this name.`,
 	DriveSheetNotFoundError:      `I couldn't find a sheet with the name Journal in your spreadsheet. Please make sure this sheet exists.`,
 	DriveUnknownError:            `There was an error. Unfortunately, I can't find out more details at the moment. I have already informed the engineer who will take care of the problem. Please try again later.`,
+	DriveAuthExpiredError:        `The connection to your Google account has expired. Please link Alexa with your Google account again in your Alexa app.`,
+	DrivePermissionDeniedError:   `I'm no longer allowed to access your journal in Google Drive. Please link Alexa with your Google account again in your Alexa app.`,
+	DriveRateLimitedError:        `Google Drive is receiving too many requests from me right now. Please try again in a few minutes.`,
+	DriveUnavailableError:        `Google Drive is not available right now. Please try again later.`,
 	Journal:                      `Journal`,
+	EntryNotFoundError:           `I couldn't find the entry anymore. Maybe it was changed or deleted in the meantime.`,
+	NewEntrySaveError:            `Uh oh, there was an error when I tried to save your entry.`,
+	HowWasYourDay:                `How was your day, on a scale from one to ten?`,
+	MoodSaved:                    `Thanks. I noted {{.Mood}} out of ten.`,
+	InvalidMood:                  `Please say a number from one to ten.`,
+	NoEntryToRate:                `You can rate your day after you saved a new entry.`,
+	MoodSaveError:                `Uh oh, there was an error when I tried to save your mood.`,
+	AverageMood:                  `Your average mood for {{.TimeRange}} was {{.Average}} out of ten. That's based on {{.Count}} rated entries.`,
+	NoMoodsInTimeRange:           `I couldn't find any mood ratings for {{.TimeRange}}.`,
+	HappiestDays:                 `In {{.TimeRange}}, you were happiest on {{.Dates}}, with {{.Mood}} out of ten.`,
+	CouldNotGetMoods:             `Uh oh, there was an error when I tried to retrieve your moods.`,
+	InTimeRange:                  `in {{.TimeRange}}`,
+	InTotal:                      `in total`,
+	StatisticsEntryCount:         `You wrote {{.Count}} entries {{.InTimeRange}}.`,
+	StatisticsDaysWritten:        `You wrote on {{.Count}} days {{.InTimeRange}}.`,
+	StatisticsLongestStreak:      `Your longest streak {{.InTimeRange}} was {{.Length}} days in a row, from {{.Start}} to {{.End}}.`,
+	StatisticsFirstEntry:         `Your first entry {{.InTimeRange}} is from {{.WeekDay}}, {{.Date}}.`,
+	CouldNotGetStatistics:        `Uh oh, there was an error when I tried to analyze your journal.`,
+
+	YourJournalIsNowOpenWithoutQuestion: `Okay, your journal is open.`,
+	OnThisDayIntro:                      `On this day in previous years:`,
+	OnThisDayYear:                       `In {{.Year}}: {{.Text}}.`,
+	NoEntriesOnThisDay:                  `You haven't written anything on this day in previous years yet.`,
+	OkayOnThisDayGreetingEnabled:        `Okay. From now on, when you open your journal, I'll read to you what happened on this day in previous years.`,
+	OkayOnThisDayGreetingDisabled:       `Okay. When you open your journal, I won't read to you anymore what happened on this day in previous years.`,
+	OkayMoodQuestionEnabled:             `Okay. From now on, I'll ask you how your day was after each entry.`,
+	OkayMoodQuestionDisabled:            `Okay. I won't ask you anymore how your day was after an entry. You can still rate it by saying e.g. \"rate my day with 7\".`,
+	NoMemoriesFound:                     `I couldn't find a matching memory.`,
+	NoMemoriesWithTagFound:              `I couldn't find a memory with the tag \"{{.Tag}}\".`,
+
+	ReminderText:               `Time to write in your journal.`,
+	OkayReminderSet:            `Okay. From now on, I'll remind you every day at {{.Time}} to write in your journal.`,
+	OkayReminderCancelled:      `Okay. I won't remind you anymore to write in your journal.`,
+	NoReminderToCancel:         `You don't have a daily reminder set up.`,
+	InvalidReminderTime:        `Sorry, I didn't understand that time.`,
+	RemindersPermissionMissing: `To remind you, I need permission for reminders first. Please grant it in the Alexa app.`,
+	RemindersPermissionCard:    `Please allow the journal to create reminders in the skill settings of the Alexa app.`,
+	ReminderError:              `Sorry, something went wrong while setting up your reminder.`,
+
+	GuidedPrompt:        `Here's your prompt {{.ForDate}}: {{.Prompt}}`,
+	OkayPromptSetChosen: `Okay. From now on, I'll start every new entry with a {{.PromptSet}} prompt.`,
+	OkayPromptsDisabled: `Okay. From now on, I won't start new entries with a prompt anymore.`,
+	UnknownPromptSet:    `Sorry, I don't know these prompts. You can choose between gratitude, reflection, and work log.`,
+
+	GratitudeListStart:                `Let's write down {{.Count}} good things {{.ForDate}}. I'll repeat each of them, so you can \"correct\" or \"repeat\" it.`,
+	GratitudeListStart_succinct:       `Let's write down {{.Count}} good things {{.ForDate}}.`,
+	GratitudeListItemPrompt:           `What's good thing number {{.Number}}?`,
+	GratitudeListRepeatItem:           `I repeat: {{.Text}}.`,
+	GratitudeListEmptyNoRepeat:        `You haven't named anything yet. There's nothing to repeat.`,
+	GratitudeListEmptyNoCorrect:       `You haven't named anything yet. There's nothing to correct.`,
+	GratitudeListOkayCorrect:          `OK. Please tell me number {{.Number}} again.`,
+	GratitudeListConfirmation:         `Alright. Your good things for {{.Date}}: {{.Items}}. Should I save them like this?`,
+	GratitudeListConfirmationReprompt: `Should I save your good things like this?`,
+	ListItem:                          `Number {{.Number}}: {{.Item}}`,
+
+	OkayExported: `Okay. I've saved your journal as {{.Filename}} in your Google Drive.`,
+	ExportError:  `Something went wrong while exporting your journal.`,
+
+	SomeEntriesCouldNotBeRead: `Some entries in your journal could not be read and were skipped.`,
+	OpeningJournal:            `One moment, I'm opening your journal.`,
+
+	NamedJournalSpreadsheet: `Journal - {{.Name}}`,
+	OnlyMainJournal:         `You only have your main journal. To create another one, say e.g. \"create a dream journal\".`,
+	YourJournals:            `Besides your main journal, you have these journals: {{.Names}}.`,
+	ActiveJournalIsMain:     `Your main journal is open.`,
+	ActiveJournalIs:         `Your {{.Name}} journal is open.`,
+	OkayMainJournalOpen:     `Okay, your main journal is open.`,
+	OkayJournalOpen:         `Okay, your {{.Name}} journal is open.`,
+	OkayJournalCreated:      `Okay, I've created your {{.Name}} journal and opened it.`,
+	JournalAlreadyExists:    `You already have a {{.Name}} journal. I've opened it.`,
+	UnknownJournal:          `You don't have a {{.Name}} journal yet. To create it, say \"create a {{.Name}} journal\".`,
+	MissingJournalName:      `Sorry, I didn't get the name of the journal.`,
+	CreateJournalError:      `Something went wrong while creating the journal.`,
+
+	MultipleJournalFilesFound: `I found {{.Count}} spreadsheets called {{.Name}} in your Google Drive. {{.Candidates}}. Which one should I use for your journal? Say e.g. \"number 1\".`,
+	JournalFileCandidate:      `Number {{.Number}}, last changed on {{.Date}}`,
+	CandidateDate:             `{{.Month}} {{.Day}}, {{.Year}}`,
+	WhichJournalFile:          `Which spreadsheet should I use for your journal? Say e.g. \"number 1\".`,
+	NoJournalFileToChoose:     `There's nothing to choose right now.`,
+	InvalidJournalFileNumber:  `Please say a number between 1 and {{.Count}}.`,
+	OkayJournalFileChosen:     `Okay, I'll use number {{.Number}} from now on.`,
+	MissingFolderName:         `Sorry, I didn't get the name of the folder.`,
+	FolderNotFound:            `I couldn't find a folder called {{.Folder}} in your Google Drive.`,
+	OkayFolderChosen:          `Okay, from now on I'll look for your journals in the folder {{.Folder}} and create new ones there.`,
+	JournalSplitFound:         `I found entries of your journal in several spreadsheets: {{.Name}} and {{.Others}}. That happens when you use me in different languages. Should I merge all entries into {{.Name}}, so that you only have one journal?`,
+	ShouldIMergeJournals:      `Should I merge the spreadsheets into one journal? Please say yes or no.`,
+	OkayJournalsMerged:        `Done. All entries are in {{.Name}} now. The other spreadsheets are still in your Google Drive. You can delete them when you no longer need them.`,
+	OkayJournalsNotMerged:     `Okay, from now on I'll only use {{.Name}}. The entries in the other spreadsheets stay where they are.`,
+	MergeJournalsError:        `Something went wrong while merging your journals.`,
+	MergeJournalsRetry:        `Entries that are already merged won't be copied twice. Should I try again?`,
+	NothingToConfirm:          `There's nothing to confirm right now.`,
+	DriveSplitJournalError:    `The entries of your journal are spread across several spreadsheets. Open your journal to merge them.`,
+	DriveJournalNotFoundError: `I can't find the spreadsheet of your journal in your Google Drive anymore. It may have been deleted or is no longer shared with you. Please try again, and I'll look for your journal by its name.`,
 }))
 
 func tomlStringFrom(stringMap map[StringID]string) string {
@@ -0,0 +1,83 @@
+package resources
+
+// Prompt set IDs as used by the PromptSet slot type in the interaction models.
+const (
+	PromptSetGratitude  = "GRATITUDE"
+	PromptSetReflection = "REFLECTION"
+	PromptSetWorkLog    = "WORK_LOG"
+)
+
+var promptSetNamesEn = map[string]string{
+	PromptSetGratitude:  "gratitude",
+	PromptSetReflection: "reflection",
+	PromptSetWorkLog:    "work log",
+}
+
+var PromptSetNames = map[string]map[string]string{
+	"de-DE": {
+		PromptSetGratitude:  "Dankbarkeit",
+		PromptSetReflection: "Reflexion",
+		PromptSetWorkLog:    "Arbeitstagebuch",
+	},
+	"en-US": promptSetNamesEn,
+	"en-GB": promptSetNamesEn,
+	"en-IN": promptSetNamesEn,
+	"en-CA": promptSetNamesEn,
+	"en-AU": promptSetNamesEn,
+}
+
+var promptsEn = map[string][]string{
+	PromptSetGratitude: {
+		"What are you grateful for today?",
+		"Who made your day a little better today?",
+		"What small thing made you smile today?",
+		"What is something you often take for granted that you appreciate today?",
+		"What went well today?",
+	},
+	PromptSetReflection: {
+		"What was the most important thing that happened today?",
+		"What did you learn today?",
+		"What would you do differently if you could live today again?",
+		"How did you feel today, and why?",
+		"What are you looking forward to tomorrow?",
+	},
+	PromptSetWorkLog: {
+		"What did you work on today?",
+		"What did you get done today?",
+		"What is blocking you at the moment?",
+		"What are your priorities for tomorrow?",
+		"Which decision did you make today, and why?",
+	},
+}
+
+// Prompts are the guided journaling prompts per locale and prompt set.
+var Prompts = map[string]map[string][]string{
+	"de-DE": {
+		PromptSetGratitude: {
+			"Wofür bist Du heute dankbar?",
+			"Wer hat Deinen Tag heute ein bisschen schöner gemacht?",
+			"Welche Kleinigkeit hat Dich heute zum Lächeln gebracht?",
+			"Was hältst Du oft für selbstverständlich, weißt es heute aber zu schätzen?",
+			"Was ist heute gut gelaufen?",
+		},
+		PromptSetReflection: {
+			"Was war heute das Wichtigste, das passiert ist?",
+			"Was hast Du heute gelernt?",
+			"Was würdest Du anders machen, wenn Du den Tag noch einmal erleben könntest?",
+			"Wie hast Du Dich heute gefühlt, und warum?",
+			"Worauf freust Du Dich morgen?",
+		},
+		PromptSetWorkLog: {
+			"Woran hast Du heute gearbeitet?",
+			"Was hast Du heute geschafft?",
+			"Was hält Dich im Moment auf?",
+			"Was sind Deine Prioritäten für morgen?",
+			"Welche Entscheidung hast Du heute getroffen, und warum?",
+		},
+	},
+	"en-US": promptsEn,
+	"en-GB": promptsEn,
+	"en-IN": promptsEn,
+	"en-CA": promptsEn,
+	"en-AU": promptsEn,
+}
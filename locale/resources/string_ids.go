@@ -61,7 +61,96 @@ const (
 	DriveMultipleFilesFoundError
 	DriveSheetNotFoundError
 	DriveUnknownError
+	DriveAuthExpiredError
+	DrivePermissionDeniedError
+	DriveRateLimitedError
+	DriveUnavailableError
 	Journal
+	EntryNotFoundError
+	NewEntrySaveError
+	HowWasYourDay
+	MoodSaved
+	InvalidMood
+	NoEntryToRate
+	MoodSaveError
+	AverageMood
+	NoMoodsInTimeRange
+	HappiestDays
+	CouldNotGetMoods
+	InTimeRange
+	InTotal
+	StatisticsEntryCount
+	StatisticsDaysWritten
+	StatisticsLongestStreak
+	StatisticsFirstEntry
+	CouldNotGetStatistics
+	YourJournalIsNowOpenWithoutQuestion
+	OnThisDayIntro
+	OnThisDayYear
+	NoEntriesOnThisDay
+	OkayOnThisDayGreetingEnabled
+	OkayOnThisDayGreetingDisabled
+	OkayMoodQuestionEnabled
+	OkayMoodQuestionDisabled
+	NoMemoriesFound
+	NoMemoriesWithTagFound
+	ReminderText
+	OkayReminderSet
+	OkayReminderCancelled
+	NoReminderToCancel
+	InvalidReminderTime
+	RemindersPermissionMissing
+	RemindersPermissionCard
+	ReminderError
+	GuidedPrompt
+	OkayPromptSetChosen
+	OkayPromptsDisabled
+	UnknownPromptSet
+	GratitudeListStart
+	GratitudeListStart_succinct
+	GratitudeListItemPrompt
+	GratitudeListRepeatItem
+	GratitudeListEmptyNoRepeat
+	GratitudeListEmptyNoCorrect
+	GratitudeListOkayCorrect
+	GratitudeListConfirmation
+	GratitudeListConfirmationReprompt
+	ListItem
+	OkayExported
+	ExportError
+	SomeEntriesCouldNotBeRead
+	OpeningJournal
+	NamedJournalSpreadsheet
+	OnlyMainJournal
+	YourJournals
+	ActiveJournalIsMain
+	ActiveJournalIs
+	OkayMainJournalOpen
+	OkayJournalOpen
+	OkayJournalCreated
+	JournalAlreadyExists
+	UnknownJournal
+	MissingJournalName
+	CreateJournalError
+	MultipleJournalFilesFound
+	JournalFileCandidate
+	CandidateDate
+	WhichJournalFile
+	NoJournalFileToChoose
+	InvalidJournalFileNumber
+	OkayJournalFileChosen
+	MissingFolderName
+	FolderNotFound
+	OkayFolderChosen
+	JournalSplitFound
+	ShouldIMergeJournals
+	OkayJournalsMerged
+	OkayJournalsNotMerged
+	MergeJournalsError
+	NothingToConfirm
+	DriveSplitJournalError
+	MergeJournalsRetry
+	DriveJournalNotFoundError
 
 	EndMarker
 )
@@ -65,13 +65,102 @@ func _() {
 	_ = x[DriveMultipleFilesFoundError-54]
 	_ = x[DriveSheetNotFoundError-55]
 	_ = x[DriveUnknownError-56]
-	_ = x[Journal-57]
-	_ = x[EndMarker-58]
+	_ = x[DriveAuthExpiredError-57]
+	_ = x[DrivePermissionDeniedError-58]
+	_ = x[DriveRateLimitedError-59]
+	_ = x[DriveUnavailableError-60]
+	_ = x[Journal-61]
+	_ = x[EntryNotFoundError-62]
+	_ = x[NewEntrySaveError-63]
+	_ = x[HowWasYourDay-64]
+	_ = x[MoodSaved-65]
+	_ = x[InvalidMood-66]
+	_ = x[NoEntryToRate-67]
+	_ = x[MoodSaveError-68]
+	_ = x[AverageMood-69]
+	_ = x[NoMoodsInTimeRange-70]
+	_ = x[HappiestDays-71]
+	_ = x[CouldNotGetMoods-72]
+	_ = x[InTimeRange-73]
+	_ = x[InTotal-74]
+	_ = x[StatisticsEntryCount-75]
+	_ = x[StatisticsDaysWritten-76]
+	_ = x[StatisticsLongestStreak-77]
+	_ = x[StatisticsFirstEntry-78]
+	_ = x[CouldNotGetStatistics-79]
+	_ = x[YourJournalIsNowOpenWithoutQuestion-80]
+	_ = x[OnThisDayIntro-81]
+	_ = x[OnThisDayYear-82]
+	_ = x[NoEntriesOnThisDay-83]
+	_ = x[OkayOnThisDayGreetingEnabled-84]
+	_ = x[OkayOnThisDayGreetingDisabled-85]
+	_ = x[OkayMoodQuestionEnabled-86]
+	_ = x[OkayMoodQuestionDisabled-87]
+	_ = x[NoMemoriesFound-88]
+	_ = x[NoMemoriesWithTagFound-89]
+	_ = x[ReminderText-90]
+	_ = x[OkayReminderSet-91]
+	_ = x[OkayReminderCancelled-92]
+	_ = x[NoReminderToCancel-93]
+	_ = x[InvalidReminderTime-94]
+	_ = x[RemindersPermissionMissing-95]
+	_ = x[RemindersPermissionCard-96]
+	_ = x[ReminderError-97]
+	_ = x[GuidedPrompt-98]
+	_ = x[OkayPromptSetChosen-99]
+	_ = x[OkayPromptsDisabled-100]
+	_ = x[UnknownPromptSet-101]
+	_ = x[GratitudeListStart-102]
+	_ = x[GratitudeListStart_succinct-103]
+	_ = x[GratitudeListItemPrompt-104]
+	_ = x[GratitudeListRepeatItem-105]
+	_ = x[GratitudeListEmptyNoRepeat-106]
+	_ = x[GratitudeListEmptyNoCorrect-107]
+	_ = x[GratitudeListOkayCorrect-108]
+	_ = x[GratitudeListConfirmation-109]
+	_ = x[GratitudeListConfirmationReprompt-110]
+	_ = x[ListItem-111]
+	_ = x[OkayExported-112]
+	_ = x[ExportError-113]
+	_ = x[SomeEntriesCouldNotBeRead-114]
+	_ = x[OpeningJournal-115]
+	_ = x[NamedJournalSpreadsheet-116]
+	_ = x[OnlyMainJournal-117]
+	_ = x[YourJournals-118]
+	_ = x[ActiveJournalIsMain-119]
+	_ = x[ActiveJournalIs-120]
+	_ = x[OkayMainJournalOpen-121]
+	_ = x[OkayJournalOpen-122]
+	_ = x[OkayJournalCreated-123]
+	_ = x[JournalAlreadyExists-124]
+	_ = x[UnknownJournal-125]
+	_ = x[MissingJournalName-126]
+	_ = x[CreateJournalError-127]
+	_ = x[MultipleJournalFilesFound-128]
+	_ = x[JournalFileCandidate-129]
+	_ = x[CandidateDate-130]
+	_ = x[WhichJournalFile-131]
+	_ = x[NoJournalFileToChoose-132]
+	_ = x[InvalidJournalFileNumber-133]
+	_ = x[OkayJournalFileChosen-134]
+	_ = x[MissingFolderName-135]
+	_ = x[FolderNotFound-136]
+	_ = x[OkayFolderChosen-137]
+	_ = x[JournalSplitFound-138]
+	_ = x[ShouldIMergeJournals-139]
+	_ = x[OkayJournalsMerged-140]
+	_ = x[OkayJournalsNotMerged-141]
+	_ = x[MergeJournalsError-142]
+	_ = x[NothingToConfirm-143]
+	_ = x[DriveSplitJournalError-144]
+	_ = x[MergeJournalsRetry-145]
+	_ = x[DriveJournalNotFoundError-146]
+	_ = x[EndMarker-147]
 }
 
-const _StringID_name = "YourJournalIsNowOpenNewEntryDraftExistsYouCanNowCreateYourEntryYouCanNowCreateYourEntry_succinctForDateIRepeatNextPartPleaseRepromptYourEntryIsEmptyNoRepeatYourEntryIsEmptyNoCorrectOkayCorrectPartCorrectPartRepromptNewEntryAbortedYourEntryIsEmptyNoSaveNewEntryConfirmationNewEntryConfirmationRepromptOkaySavedOkayNotSavedSuccinctModeExplanationWhatDoYouWantToDoNextDidNotUnderstandTryAgainExampleRelativeDateQueryExampleDateQueryCouldNotGetEntryCouldNotGetEntriesNoEntriesInTimeRangeFoundEntriesInTimeRangeReadEntryJournalIsEmptyNewEntryExampleEntryForDateNotFoundSearchErrorSearchNoResultsFoundSearchResultsDeleteEntryNotFoundDeleteEntryCouldNotGetEntryDeleteEntryConfirmationDeleteEntryErrorOkayDeletedOkayNotDeletedLinkWithGoogleAccountOkayWillBeSuccinctOkayWillBeVerboseInvalidDateInternalErrorHelpDoneCorrect1Correct2Repeat1Repeat2AbortShortPauseLongPauseDriveCannotCreateFileErrorDriveMultipleFilesFoundErrorDriveSheetNotFoundErrorDriveUnknownErrorJournalEndMarker"
+const _StringID_name = "YourJournalIsNowOpenNewEntryDraftExistsYouCanNowCreateYourEntryYouCanNowCreateYourEntry_succinctForDateIRepeatNextPartPleaseRepromptYourEntryIsEmptyNoRepeatYourEntryIsEmptyNoCorrectOkayCorrectPartCorrectPartRepromptNewEntryAbortedYourEntryIsEmptyNoSaveNewEntryConfirmationNewEntryConfirmationRepromptOkaySavedOkayNotSavedSuccinctModeExplanationWhatDoYouWantToDoNextDidNotUnderstandTryAgainExampleRelativeDateQueryExampleDateQueryCouldNotGetEntryCouldNotGetEntriesNoEntriesInTimeRangeFoundEntriesInTimeRangeReadEntryJournalIsEmptyNewEntryExampleEntryForDateNotFoundSearchErrorSearchNoResultsFoundSearchResultsDeleteEntryNotFoundDeleteEntryCouldNotGetEntryDeleteEntryConfirmationDeleteEntryErrorOkayDeletedOkayNotDeletedLinkWithGoogleAccountOkayWillBeSuccinctOkayWillBeVerboseInvalidDateInternalErrorHelpDoneCorrect1Correct2Repeat1Repeat2AbortShortPauseLongPauseDriveCannotCreateFileErrorDriveMultipleFilesFoundErrorDriveSheetNotFoundErrorDriveUnknownErrorDriveAuthExpiredErrorDrivePermissionDeniedErrorDriveRateLimitedErrorDriveUnavailableErrorJournalEntryNotFoundErrorNewEntrySaveErrorHowWasYourDayMoodSavedInvalidMoodNoEntryToRateMoodSaveErrorAverageMoodNoMoodsInTimeRangeHappiestDaysCouldNotGetMoodsInTimeRangeInTotalStatisticsEntryCountStatisticsDaysWrittenStatisticsLongestStreakStatisticsFirstEntryCouldNotGetStatisticsYourJournalIsNowOpenWithoutQuestionOnThisDayIntroOnThisDayYearNoEntriesOnThisDayOkayOnThisDayGreetingEnabledOkayOnThisDayGreetingDisabledOkayMoodQuestionEnabledOkayMoodQuestionDisabledNoMemoriesFoundNoMemoriesWithTagFoundReminderTextOkayReminderSetOkayReminderCancelledNoReminderToCancelInvalidReminderTimeRemindersPermissionMissingRemindersPermissionCardReminderErrorGuidedPromptOkayPromptSetChosenOkayPromptsDisabledUnknownPromptSetGratitudeListStartGratitudeListStart_succinctGratitudeListItemPromptGratitudeListRepeatItemGratitudeListEmptyNoRepeatGratitudeListEmptyNoCorrectGratitudeListOkayCorrectGratitudeListConfirmationGratitudeListConfirmationRepromptListItemOkayExportedExportErrorSomeEntriesCouldNotBeReadOpeningJournalNamedJournalSpreadsheetOnlyMainJournalYourJournalsActiveJournalIsMainActiveJournalIsOkayMainJournalOpenOkayJournalOpenOkayJournalCreatedJournalAlreadyExistsUnknownJournalMissingJournalNameCreateJournalErrorMultipleJournalFilesFoundJournalFileCandidateCandidateDateWhichJournalFileNoJournalFileToChooseInvalidJournalFileNumberOkayJournalFileChosenMissingFolderNameFolderNotFoundOkayFolderChosenJournalSplitFoundShouldIMergeJournalsOkayJournalsMergedOkayJournalsNotMergedMergeJournalsErrorNothingToConfirmDriveSplitJournalErrorMergeJournalsRetryDriveJournalNotFoundErrorEndMarker"
 
-var _StringID_index = [...]uint16{0, 20, 39, 63, 96, 103, 110, 132, 156, 181, 196, 215, 230, 252, 272, 300, 309, 321, 344, 365, 389, 413, 429, 445, 463, 488, 506, 515, 529, 544, 564, 575, 595, 608, 627, 654, 677, 693, 704, 718, 739, 757, 774, 785, 798, 802, 806, 814, 822, 829, 836, 841, 851, 860, 886, 914, 937, 954, 961, 970}
+var _StringID_index = [...]uint16{0, 20, 39, 63, 96, 103, 110, 132, 156, 181, 196, 215, 230, 252, 272, 300, 309, 321, 344, 365, 389, 413, 429, 445, 463, 488, 506, 515, 529, 544, 564, 575, 595, 608, 627, 654, 677, 693, 704, 718, 739, 757, 774, 785, 798, 802, 806, 814, 822, 829, 836, 841, 851, 860, 886, 914, 937, 954, 975, 1001, 1022, 1043, 1050, 1068, 1085, 1098, 1107, 1118, 1131, 1144, 1155, 1173, 1185, 1201, 1212, 1219, 1239, 1260, 1283, 1303, 1324, 1359, 1373, 1386, 1404, 1432, 1461, 1484, 1508, 1523, 1545, 1557, 1572, 1593, 1611, 1630, 1656, 1679, 1692, 1704, 1723, 1742, 1758, 1776, 1803, 1826, 1849, 1875, 1902, 1926, 1951, 1984, 1992, 2004, 2015, 2040, 2054, 2077, 2092, 2104, 2123, 2138, 2157, 2172, 2190, 2210, 2224, 2242, 2260, 2285, 2305, 2318, 2334, 2355, 2379, 2400, 2417, 2431, 2447, 2464, 2484, 2502, 2523, 2541, 2557, 2579, 2597, 2622, 2631}
 
 func (i StringID) String() string {
 	if i < 0 || i >= StringID(len(_StringID_index)-1) {
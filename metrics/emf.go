@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EMF writes every value as a JSON line in CloudWatch Embedded Metric Format. When written to stdout in Lambda,
+// CloudWatch Logs extracts the metrics from these lines asynchronously, so recording a value costs no API call.
+type EMF struct {
+	Writer    io.Writer
+	Namespace string
+
+	mutex sync.Mutex
+}
+
+type emfMetadata struct {
+	Timestamp         int64                `json:"Timestamp"`
+	CloudWatchMetrics []emfMetricDirective `json:"CloudWatchMetrics"`
+}
+
+type emfMetricDirective struct {
+	Namespace  string                `json:"Namespace"`
+	Dimensions [][]string            `json:"Dimensions"`
+	Metrics    []emfMetricDefinition `json:"Metrics"`
+}
+
+type emfMetricDefinition struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+func (m *EMF) Count(name string, value float64, dimensions Dimensions) {
+	m.write(name, "Count", value, dimensions)
+}
+
+func (m *EMF) Duration(name string, d time.Duration, dimensions Dimensions) {
+	m.write(name, "Milliseconds", float64(d)/float64(time.Millisecond), dimensions)
+}
+
+func (m *EMF) write(name string, unit string, value float64, dimensions Dimensions) {
+	dimensionKeys := make([]string, 0, len(dimensions))
+	line := make(map[string]interface{}, len(dimensions)+2)
+	for key, dimensionValue := range dimensions {
+		dimensionKeys = append(dimensionKeys, key)
+		line[key] = dimensionValue
+	}
+	sort.Strings(dimensionKeys)
+	line[name] = value
+	line["_aws"] = emfMetadata{
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		CloudWatchMetrics: []emfMetricDirective{{
+			Namespace:  m.Namespace,
+			Dimensions: [][]string{dimensionKeys},
+			Metrics:    []emfMetricDefinition{{Name: name, Unit: unit}},
+		}},
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	// Metrics are best effort. A failing stdout is not worth failing a request for.
+	json.NewEncoder(m.Writer).Encode(line)
+}
@@ -0,0 +1,45 @@
+// Package metrics records how often the skill is used, how long requests and backend calls take and how often
+// they fail. EMF writes the numbers to stdout in CloudWatch Embedded Metric Format for Lambda; Prometheus exposes
+// them for scraping when the skill runs as an HTTP server.
+package metrics
+
+import "time"
+
+// Metrics records counts and durations. Names are lower snake case, e.g. "sheets_api_call"; implementations may
+// add prefixes or unit suffixes.
+//
+// The same name must always be used with the same dimension keys.
+type Metrics interface {
+	Count(name string, value float64, dimensions Dimensions)
+	Duration(name string, d time.Duration, dimensions Dimensions)
+}
+
+// Dimensions break a metric down, e.g. by intent or operation. Keep their values few; never use user data.
+type Dimensions map[string]string
+
+// Nop records nothing.
+type Nop struct{}
+
+func (Nop) Count(name string, value float64, dimensions Dimensions)      {}
+func (Nop) Duration(name string, d time.Duration, dimensions Dimensions) {}
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Measure records how long the call named name took since start, with an "outcome" dimension telling whether it
+// failed with e. m may be nil, so that callers don't have to be configured with metrics.
+func Measure(m Metrics, name string, start time.Time, e error, dimensions Dimensions) {
+	if m == nil {
+		return
+	}
+	result := Dimensions{"outcome": OutcomeSuccess}
+	if e != nil {
+		result["outcome"] = OutcomeError
+	}
+	for key, value := range dimensions {
+		result[key] = value
+	}
+	m.Duration(name, time.Since(start), result)
+}
@@ -0,0 +1,68 @@
+package metrics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/petergtz/alexa-journal/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("EMF", func() {
+		It("writes one JSON line per value with the metric's definition", func() {
+			var out bytes.Buffer
+			m := &metrics.EMF{Writer: &out, Namespace: "AlexaJournal"}
+
+			m.Duration("request", 1500*time.Millisecond, metrics.Dimensions{"outcome": "success", "intent": "NewEntryIntent"})
+
+			var line map[string]interface{}
+			Expect(json.Unmarshal(out.Bytes(), &line)).To(Succeed())
+			Expect(line).To(HaveKeyWithValue("request", 1500.0))
+			Expect(line).To(HaveKeyWithValue("intent", "NewEntryIntent"))
+			Expect(line).To(HaveKeyWithValue("outcome", "success"))
+			directive := line["_aws"].(map[string]interface{})["CloudWatchMetrics"].([]interface{})[0]
+			Expect(directive).To(Equal(map[string]interface{}{
+				"Namespace":  "AlexaJournal",
+				"Dimensions": []interface{}{[]interface{}{"intent", "outcome"}},
+				"Metrics":    []interface{}{map[string]interface{}{"Name": "request", "Unit": "Milliseconds"}},
+			}))
+		})
+	})
+
+	Describe("Prometheus", func() {
+		It("exposes counts and durations", func() {
+			m := metrics.NewPrometheus("alexa_journal")
+
+			m.Count("export", 2, metrics.Dimensions{"format": "markdown"})
+			metrics.Measure(m, "sheets_api_call", time.Now(), errors.New("some error"), metrics.Dimensions{"operation": "append_row"})
+
+			recorder := httptest.NewRecorder()
+			m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+			body, e := ioutil.ReadAll(recorder.Body)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring(`alexa_journal_export_total{format="markdown"} 2`))
+			Expect(string(body)).To(ContainSubstring(
+				`alexa_journal_sheets_api_call_duration_seconds_count{operation="append_row",outcome="error"} 1`))
+		})
+
+		It("ignores values with different dimensions than the metric was first recorded with", func() {
+			m := metrics.NewPrometheus("alexa_journal")
+
+			m.Count("export", 1, metrics.Dimensions{"format": "markdown"})
+
+			Expect(func() { m.Count("export", 1, metrics.Dimensions{"other": "value"}) }).NotTo(Panic())
+		})
+	})
+
+	Describe("Measure", func() {
+		It("does nothing without metrics", func() {
+			Expect(func() { metrics.Measure(nil, "search", time.Now(), nil, nil) }).NotTo(Panic())
+		})
+	})
+})
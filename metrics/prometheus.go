@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus keeps counts as counters named <namespace>_<name>_total and durations as histograms named
+// <namespace>_<name>_duration_seconds. Serve Handler to let Prometheus scrape them.
+type Prometheus struct {
+	namespace string
+	registry  *prometheus.Registry
+
+	mutex      sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+}
+
+func NewPrometheus(namespace string) *Prometheus {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	return &Prometheus{
+		namespace:  namespace,
+		registry:   registry,
+		counters:   make(map[string]*prometheus.CounterVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+	}
+}
+
+func (m *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Prometheus) Count(name string, value float64, dimensions Dimensions) {
+	m.mutex.Lock()
+	counter, exists := m.counters[name]
+	if !exists {
+		counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: m.namespace,
+			Name:      name + "_total",
+		}, labelNamesOf(dimensions))
+		m.registry.MustRegister(counter)
+		m.counters[name] = counter
+	}
+	m.mutex.Unlock()
+
+	// A dimension mismatch is a programming error, but not one worth failing a request for.
+	if c, e := counter.GetMetricWith(prometheus.Labels(dimensions)); e == nil {
+		c.Add(value)
+	}
+}
+
+func (m *Prometheus) Duration(name string, d time.Duration, dimensions Dimensions) {
+	m.mutex.Lock()
+	histogram, exists := m.histograms[name]
+	if !exists {
+		histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: m.namespace,
+			Name:      name + "_duration_seconds",
+			Buckets:   []float64{.025, .05, .1, .25, .5, 1, 1.5, 2.5, 4, 6, 8},
+		}, labelNamesOf(dimensions))
+		m.registry.MustRegister(histogram)
+		m.histograms[name] = histogram
+	}
+	m.mutex.Unlock()
+
+	if h, e := histogram.GetMetricWith(prometheus.Labels(dimensions)); e == nil {
+		h.Observe(d.Seconds())
+	}
+}
+
+func labelNamesOf(dimensions Dimensions) []string {
+	names := make([]string, 0, len(dimensions))
+	for name := range dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
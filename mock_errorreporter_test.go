@@ -4,6 +4,7 @@
 package journalskill_test
 
 import (
+	context "context"
 	go_alexa "github.com/petergtz/go-alexa"
 	pegomock "github.com/petergtz/pegomock"
 	"reflect"
@@ -25,19 +26,19 @@ func NewMockErrorReporter(options ...pegomock.Option) *MockErrorReporter {
 func (mock *MockErrorReporter) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
 func (mock *MockErrorReporter) FailHandler() pegomock.FailHandler      { return mock.fail }
 
-func (mock *MockErrorReporter) ReportPanic(e interface{}, requestEnv *go_alexa.RequestEnvelope) {
+func (mock *MockErrorReporter) ReportPanic(ctx context.Context, e interface{}, requestEnv *go_alexa.RequestEnvelope) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockErrorReporter().")
 	}
-	params := []pegomock.Param{e, requestEnv}
+	params := []pegomock.Param{ctx, e, requestEnv}
 	pegomock.GetGenericMockFrom(mock).Invoke("ReportPanic", params, []reflect.Type{})
 }
 
-func (mock *MockErrorReporter) ReportError(e error) {
+func (mock *MockErrorReporter) ReportError(ctx context.Context, e error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockErrorReporter().")
 	}
-	params := []pegomock.Param{e}
+	params := []pegomock.Param{ctx, e}
 	pegomock.GetGenericMockFrom(mock).Invoke("ReportError", params, []reflect.Type{})
 }
 
@@ -78,8 +79,8 @@ type VerifierMockErrorReporter struct {
 	timeout                time.Duration
 }
 
-func (verifier *VerifierMockErrorReporter) ReportPanic(e interface{}, requestEnv *go_alexa.RequestEnvelope) *MockErrorReporter_ReportPanic_OngoingVerification {
-	params := []pegomock.Param{e, requestEnv}
+func (verifier *VerifierMockErrorReporter) ReportPanic(ctx context.Context, e interface{}, requestEnv *go_alexa.RequestEnvelope) *MockErrorReporter_ReportPanic_OngoingVerification {
+	params := []pegomock.Param{ctx, e, requestEnv}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ReportPanic", params, verifier.timeout)
 	return &MockErrorReporter_ReportPanic_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
 }
@@ -89,28 +90,32 @@ type MockErrorReporter_ReportPanic_OngoingVerification struct {
 	methodInvocations []pegomock.MethodInvocation
 }
 
-func (c *MockErrorReporter_ReportPanic_OngoingVerification) GetCapturedArguments() (interface{}, *go_alexa.RequestEnvelope) {
-	e, requestEnv := c.GetAllCapturedArguments()
-	return e[len(e)-1], requestEnv[len(requestEnv)-1]
+func (c *MockErrorReporter_ReportPanic_OngoingVerification) GetCapturedArguments() (context.Context, interface{}, *go_alexa.RequestEnvelope) {
+	ctx, e, requestEnv := c.GetAllCapturedArguments()
+	return ctx[len(ctx)-1], e[len(e)-1], requestEnv[len(requestEnv)-1]
 }
 
-func (c *MockErrorReporter_ReportPanic_OngoingVerification) GetAllCapturedArguments() (_param0 []interface{}, _param1 []*go_alexa.RequestEnvelope) {
+func (c *MockErrorReporter_ReportPanic_OngoingVerification) GetAllCapturedArguments() (_param0 []context.Context, _param1 []interface{}, _param2 []*go_alexa.RequestEnvelope) {
 	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
 	if len(params) > 0 {
-		_param0 = make([]interface{}, len(c.methodInvocations))
+		_param0 = make([]context.Context, len(c.methodInvocations))
 		for u, param := range params[0] {
-			_param0[u] = param.(interface{})
+			_param0[u] = param.(context.Context)
 		}
-		_param1 = make([]*go_alexa.RequestEnvelope, len(c.methodInvocations))
+		_param1 = make([]interface{}, len(c.methodInvocations))
 		for u, param := range params[1] {
-			_param1[u] = param.(*go_alexa.RequestEnvelope)
+			_param1[u] = param.(interface{})
+		}
+		_param2 = make([]*go_alexa.RequestEnvelope, len(c.methodInvocations))
+		for u, param := range params[2] {
+			_param2[u] = param.(*go_alexa.RequestEnvelope)
 		}
 	}
 	return
 }
 
-func (verifier *VerifierMockErrorReporter) ReportError(e error) *MockErrorReporter_ReportError_OngoingVerification {
-	params := []pegomock.Param{e}
+func (verifier *VerifierMockErrorReporter) ReportError(ctx context.Context, e error) *MockErrorReporter_ReportError_OngoingVerification {
+	params := []pegomock.Param{ctx, e}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ReportError", params, verifier.timeout)
 	return &MockErrorReporter_ReportError_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
 }
@@ -120,17 +125,21 @@ type MockErrorReporter_ReportError_OngoingVerification struct {
 	methodInvocations []pegomock.MethodInvocation
 }
 
-func (c *MockErrorReporter_ReportError_OngoingVerification) GetCapturedArguments() error {
-	e := c.GetAllCapturedArguments()
-	return e[len(e)-1]
+func (c *MockErrorReporter_ReportError_OngoingVerification) GetCapturedArguments() (context.Context, error) {
+	ctx, e := c.GetAllCapturedArguments()
+	return ctx[len(ctx)-1], e[len(e)-1]
 }
 
-func (c *MockErrorReporter_ReportError_OngoingVerification) GetAllCapturedArguments() (_param0 []error) {
+func (c *MockErrorReporter_ReportError_OngoingVerification) GetAllCapturedArguments() (_param0 []context.Context, _param1 []error) {
 	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
 	if len(params) > 0 {
-		_param0 = make([]error, len(c.methodInvocations))
+		_param0 = make([]context.Context, len(c.methodInvocations))
 		for u, param := range params[0] {
-			_param0[u] = param.(error)
+			_param0[u] = param.(context.Context)
+		}
+		_param1 = make([]error, len(c.methodInvocations))
+		for u, param := range params[1] {
+			_param1[u] = param.(error)
 		}
 	}
 	return
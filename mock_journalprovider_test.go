@@ -4,6 +4,8 @@
 package journalskill_test
 
 import (
+	context "context"
+	alexa_journal "github.com/petergtz/alexa-journal"
 	journal "github.com/petergtz/alexa-journal/journal"
 	pegomock "github.com/petergtz/pegomock"
 	"reflect"
@@ -25,11 +27,11 @@ func NewMockJournalProvider(options ...pegomock.Option) *MockJournalProvider {
 func (mock *MockJournalProvider) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
 func (mock *MockJournalProvider) FailHandler() pegomock.FailHandler      { return mock.fail }
 
-func (mock *MockJournalProvider) Get(accessToken string, spreadsheetName string) (journal.Journal, error) {
+func (mock *MockJournalProvider) Get(ctx context.Context, accessToken string, location alexa_journal.JournalLocation) (journal.Journal, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockJournalProvider().")
 	}
-	params := []pegomock.Param{accessToken, spreadsheetName}
+	params := []pegomock.Param{ctx, accessToken, location}
 	result := pegomock.GetGenericMockFrom(mock).Invoke("Get", params, []reflect.Type{reflect.TypeOf((*journal.Journal)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
 	var ret0 journal.Journal
 	var ret1 error
@@ -44,6 +46,25 @@ func (mock *MockJournalProvider) Get(accessToken string, spreadsheetName string)
 	return ret0, ret1
 }
 
+func (mock *MockJournalProvider) FolderID(ctx context.Context, accessToken string, folderName string) (string, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockJournalProvider().")
+	}
+	params := []pegomock.Param{ctx, accessToken, folderName}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("FolderID", params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 string
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(string)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockJournalProvider) VerifyWasCalledOnce() *VerifierMockJournalProvider {
 	return &VerifierMockJournalProvider{
 		mock:                   mock,
@@ -81,8 +102,8 @@ type VerifierMockJournalProvider struct {
 	timeout                time.Duration
 }
 
-func (verifier *VerifierMockJournalProvider) Get(accessToken string, spreadsheetName string) *MockJournalProvider_Get_OngoingVerification {
-	params := []pegomock.Param{accessToken, spreadsheetName}
+func (verifier *VerifierMockJournalProvider) Get(ctx context.Context, accessToken string, location alexa_journal.JournalLocation) *MockJournalProvider_Get_OngoingVerification {
+	params := []pegomock.Param{ctx, accessToken, location}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Get", params, verifier.timeout)
 	return &MockJournalProvider_Get_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
 }
@@ -92,22 +113,61 @@ type MockJournalProvider_Get_OngoingVerification struct {
 	methodInvocations []pegomock.MethodInvocation
 }
 
-func (c *MockJournalProvider_Get_OngoingVerification) GetCapturedArguments() (string, string) {
-	accessToken, spreadsheetName := c.GetAllCapturedArguments()
-	return accessToken[len(accessToken)-1], spreadsheetName[len(spreadsheetName)-1]
+func (c *MockJournalProvider_Get_OngoingVerification) GetCapturedArguments() (context.Context, string, alexa_journal.JournalLocation) {
+	ctx, accessToken, location := c.GetAllCapturedArguments()
+	return ctx[len(ctx)-1], accessToken[len(accessToken)-1], location[len(location)-1]
+}
+
+func (c *MockJournalProvider_Get_OngoingVerification) GetAllCapturedArguments() (_param0 []context.Context, _param1 []string, _param2 []alexa_journal.JournalLocation) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]context.Context, len(c.methodInvocations))
+		for u, param := range params[0] {
+			_param0[u] = param.(context.Context)
+		}
+		_param1 = make([]string, len(c.methodInvocations))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]alexa_journal.JournalLocation, len(c.methodInvocations))
+		for u, param := range params[2] {
+			_param2[u] = param.(alexa_journal.JournalLocation)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockJournalProvider) FolderID(ctx context.Context, accessToken string, folderName string) *MockJournalProvider_FolderID_OngoingVerification {
+	params := []pegomock.Param{ctx, accessToken, folderName}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "FolderID", params, verifier.timeout)
+	return &MockJournalProvider_FolderID_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockJournalProvider_FolderID_OngoingVerification struct {
+	mock              *MockJournalProvider
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockJournalProvider_FolderID_OngoingVerification) GetCapturedArguments() (context.Context, string, string) {
+	ctx, accessToken, folderName := c.GetAllCapturedArguments()
+	return ctx[len(ctx)-1], accessToken[len(accessToken)-1], folderName[len(folderName)-1]
 }
 
-func (c *MockJournalProvider_Get_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string) {
+func (c *MockJournalProvider_FolderID_OngoingVerification) GetAllCapturedArguments() (_param0 []context.Context, _param1 []string, _param2 []string) {
 	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
 	if len(params) > 0 {
-		_param0 = make([]string, len(c.methodInvocations))
+		_param0 = make([]context.Context, len(c.methodInvocations))
 		for u, param := range params[0] {
-			_param0[u] = param.(string)
+			_param0[u] = param.(context.Context)
 		}
 		_param1 = make([]string, len(c.methodInvocations))
 		for u, param := range params[1] {
 			_param1[u] = param.(string)
 		}
+		_param2 = make([]string, len(c.methodInvocations))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
 	}
 	return
 }
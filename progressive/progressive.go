@@ -0,0 +1,69 @@
+// Package progressive sends progressive responses via the Alexa Progressive Response API, so that users hear
+// something while the skill is still busy building the actual response.
+package progressive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/petergtz/alexa-journal/tracing"
+	"github.com/pkg/errors"
+)
+
+// Client speaks progressive responses. Its HTTPClient can be replaced, e.g. to talk to a local fake endpoint.
+type Client struct {
+	HTTPClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{HTTPClient: httpClient}
+}
+
+type directiveRequest struct {
+	Header    header    `json:"header"`
+	Directive directive `json:"directive"`
+}
+
+type header struct {
+	RequestID string `json:"requestId"`
+}
+
+type directive struct {
+	Type   string `json:"type"`
+	Speech string `json:"speech"`
+}
+
+// Speak makes Alexa speak speech while the skill is still processing the request with the given requestID.
+func (c *Client) Speak(ctx context.Context, apiEndpoint string, apiAccessToken string, requestID string, speech string) (err error) {
+	ctx, span := tracing.Start(ctx, "alexa.progressive_response")
+	defer func() { tracing.End(span, err) }()
+
+	body, e := json.Marshal(directiveRequest{
+		Header:    header{RequestID: requestID},
+		Directive: directive{Type: "VoicePlayer.Speak", Speech: speech},
+	})
+	if e != nil {
+		return errors.Wrap(e, "Could not marshal directive request")
+	}
+	req, e := http.NewRequest(http.MethodPost, apiEndpoint+"/v1/directives", bytes.NewReader(body))
+	if e != nil {
+		return errors.Wrap(e, "Could not create directive request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiAccessToken)
+
+	resp, e := c.HTTPClient.Do(req)
+	if e != nil {
+		return errors.Wrapf(e, "Could not send directive request %v %v", req.Method, req.URL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("Unexpected status code %v from Progressive Response API. Body: %v", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package progressive_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProgressive(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Progressive Suite")
+}
@@ -0,0 +1,63 @@
+package progressive_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/progressive"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server         *httptest.Server
+		client         *Client
+		statusCode     int
+		receivedMethod string
+		receivedPath   string
+		receivedAuth   string
+		receivedBody   map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		statusCode = http.StatusNoContent
+		receivedBody = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			receivedMethod = req.Method
+			receivedPath = req.URL.Path
+			receivedAuth = req.Header.Get("Authorization")
+			body, e := ioutil.ReadAll(req.Body)
+			Expect(e).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(body, &receivedBody)).To(Succeed())
+			w.WriteHeader(statusCode)
+		}))
+		client = NewClient(server.Client())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("sends a VoicePlayer.Speak directive for the request", func() {
+		e := client.Speak(context.Background(), server.URL, "some-token", "some-request-id", "One moment.")
+
+		Expect(e).NotTo(HaveOccurred())
+		Expect(receivedMethod).To(Equal("POST"))
+		Expect(receivedPath).To(Equal("/v1/directives"))
+		Expect(receivedAuth).To(Equal("Bearer some-token"))
+		Expect(receivedBody).To(Equal(map[string]interface{}{
+			"header":    map[string]interface{}{"requestId": "some-request-id"},
+			"directive": map[string]interface{}{"type": "VoicePlayer.Speak", "speech": "One moment."},
+		}))
+	})
+
+	It("returns an error when the API rejects the directive", func() {
+		statusCode = http.StatusBadRequest
+
+		Expect(client.Speak(context.Background(), server.URL, "some-token", "some-request-id", "One moment.")).NotTo(Succeed())
+	})
+})
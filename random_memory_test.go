@@ -0,0 +1,55 @@
+package journalskill_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal"
+	j "github.com/petergtz/alexa-journal/journal"
+)
+
+var _ = Describe("Random memories", func() {
+	entries := []j.Entry{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	It("prefers entries that weren't served recently", func() {
+		for i := 0; i < 20; i++ {
+			Expect(RandomEntryNotIn([]string{"a", "c"}, entries).ID).To(Equal("b"))
+		}
+	})
+
+	It("prefers the least recently served entries once all were served", func() {
+		for i := 0; i < 20; i++ {
+			Expect(RandomEntryNotIn([]string{"b", "a", "c"}, entries).ID).To(Equal("b"))
+		}
+	})
+
+	It("picks among all entries that weren't served recently", func() {
+		picked := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			picked[RandomEntryNotIn([]string{"a"}, entries).ID] = true
+		}
+		Expect(picked).To(Equal(map[string]bool{"b": true, "c": true}))
+	})
+
+	It("rotates through all entries", func() {
+		var recentIDs []string
+		for i := 0; i < len(entries); i++ {
+			recentIDs = RecentMemoryIDsWith(recentIDs, RandomEntryNotIn(recentIDs, entries).ID)
+		}
+		Expect(recentIDs).To(ConsistOf("a", "b", "c"))
+
+		Expect(RandomEntryNotIn(recentIDs, entries).ID).To(Equal(recentIDs[0]))
+	})
+
+	It("only remembers the most recent memories", func() {
+		var recentIDs []string
+		for i := 0; i <= MaxRecentMemories; i++ {
+			recentIDs = RecentMemoryIDsWith(recentIDs, fmt.Sprint(i))
+		}
+
+		Expect(recentIDs).To(HaveLen(MaxRecentMemories))
+		Expect(recentIDs[0]).To(Equal("1"))
+		Expect(recentIDs[MaxRecentMemories-1]).To(Equal(fmt.Sprint(MaxRecentMemories)))
+	})
+})
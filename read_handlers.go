@@ -0,0 +1,258 @@
+package journalskill
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petergtz/alexa-journal/export"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	"github.com/petergtz/alexa-journal/util"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
+)
+
+func (h *JournalSkill) onThisDay(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	today := date.Today()
+	entries, e := in.Journal.GetEntriesOnDayOfYear(in.Ctx, today.Month(), today.Day(), today.Year())
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntries, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		return in.Response().Speak(h.parseWarning(in) + l.Get(r.NoEntriesOnThisDay, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	return in.Response().Speak(h.parseWarning(in) + onThisDayText(entries, l) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+func (h *JournalSkill) randomMemory(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	timeRange := ""
+	if intent.Slots["date"].Value != "" {
+		var ok bool
+		timeRange, ok = TimeRangeFrom(intent.Slots["date"].Value)
+		if !ok {
+			return in.Response().Speak(l.Get(r.DidNotUnderstandTryAgain)).Build()
+		}
+	}
+	tag := intent.Slots["tag"].Value
+	entries, e := in.Journal.GetEntriesWithTag(in.Ctx, timeRange, tag)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntries, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		if tag != "" {
+			return in.Response().
+				Speak(l.GetTemplated(r.NoMemoriesWithTagFound, map[string]interface{}{"Tag": tag}) +
+					l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+				Build()
+		}
+		return in.Response().Speak(l.Get(r.NoMemoriesFound, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	entry := randomEntryNotIn(in.Config.RecentMemoryIDs, entries)
+
+	// Entries written before entries had IDs can't be remembered, so they might be picked again.
+	if entry.ID != "" {
+		newConfig := in.Config
+		newConfig.RecentMemoryIDs = recentMemoryIDsWith(in.Config.RecentMemoryIDs, entry.ID)
+		h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	}
+
+	return in.Response().
+		Speak(l.GetTemplated(r.ReadEntry, map[string]interface{}{
+			"WeekDay": l.Weekday(entry.EntryDate.Weekday()),
+			"Date":    entry.EntryDate.String(),
+			"Text":    spokenTextOf(entry, l),
+		}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) listAllEntriesInDate(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	switch in.RequestEnv.Request.DialogState {
+	case "STARTED", "IN_PROGRESS":
+		return in.Response().Delegate(&intent).Build()
+	case "COMPLETED":
+		_, monthDate, dateType := DateFrom(intent.Slots["date"].Value)
+		if dateType == MonthDate {
+			return h.entriesInMonth(in, monthDate)
+		}
+		return in.Response().
+			Speak(l.Get(r.DidNotUnderstandTryAgain)).
+			Reprompt(l.Get(r.DidNotUnderstandTryAgain)).
+			Build()
+	default:
+		panic(errors.New("Invalid requestEnv.Request.DialogState"))
+	}
+}
+
+func (h *JournalSkill) readEntryAbsoluteDate(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	switch in.RequestEnv.Request.DialogState {
+	case "STARTED", "IN_PROGRESS":
+		return in.Response().Delegate(&intent).Build()
+	case "COMPLETED":
+		entryDate, monthDate, dateType := DateFrom(intent.Slots["date"].Value)
+		if dateType == Invalid {
+			return in.Response().
+				Speak(fmt.Sprintf(l.Get(r.DidNotUnderstandTryAgain, r.ExampleDateQuery))).
+				Reprompt(l.Get(r.DidNotUnderstandTryAgain)).
+				Build()
+		}
+		if dateType == MonthDate {
+			return h.entriesInMonth(in, monthDate)
+		}
+		return h.entryOn(in, entryDate)
+	default:
+		panic(errors.New("Invalid requestEnv.Request.DialogState"))
+	}
+}
+
+func (h *JournalSkill) readEntryRelativeDate(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	today := date.NewAt(time.Now())
+	x, e := strconv.Atoi(intent.Slots["number"].Value)
+	if e != nil ||
+		intent.Slots["unit"].Resolutions.ResolutionsPerAuthority[0].Status["code"] == "ER_SUCCESS_NO_MATCH" {
+		return in.ResponseWithSession().
+			Speak(l.Get(r.DidNotUnderstandTryAgain, r.ShortPause, r.ExampleRelativeDateQuery)).
+			ElicitSlot("unit").
+			Build()
+	}
+	var entryDate date.Date
+	switch intent.Slots["unit"].Resolutions.ResolutionsPerAuthority[0].Values[0].Value.ID {
+	case "DAYS":
+		entryDate = today.AddDate(0, 0, -x)
+	case "MONTHS":
+		entryDate = today.AddDate(0, -x, 0)
+	case "YEARS":
+		entryDate = today.AddDate(-x, 0, 0)
+	default:
+		panic(errors.New("Invalid resolution"))
+	}
+	return h.entryOn(in, entryDate)
+}
+
+// entryOn reads the entries on entryDate or, if there are none, the closest entry.
+func (h *JournalSkill) entryOn(in *Input, entryDate date.Date) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	text, e := spokenEntryTextOn(in.Ctx, &in.Journal, entryDate, l)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntry, r.ShortPause), e)
+	}
+	if text != "" {
+		return in.Response().
+			Speak(l.GetTemplated(r.ReadEntry, map[string]interface{}{
+				"WeekDay": l.Weekday(entryDate.Weekday()),
+				"Date":    entryDate.String(),
+				"Text":    text,
+			}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	closestEntry, e := in.Journal.GetClosestEntry(in.Ctx, entryDate)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntry, r.ShortPause), e)
+	}
+	if closestEntry.EntryDate.IsZero() {
+		return in.Response().Speak(l.Get(r.JournalIsEmpty, r.LongPause, r.WhatDoYouWantToDoNext, r.ShortPause, r.NewEntryExample)).Build()
+	}
+	return in.Response().
+		Speak(l.GetTemplated(r.EntryForDateNotFound, map[string]interface{}{
+			"SearchDate": entryDate.String(),
+			"WeekDay":    l.Weekday(closestEntry.EntryDate.Weekday()),
+			"Date":       closestEntry.EntryDate.String(),
+			"Text":       spokenTextOf(closestEntry, l),
+		}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+// entriesInMonth reads all entries in the month of dateSlotValue.
+func (h *JournalSkill) entriesInMonth(in *Input, dateSlotValue string) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	entries, e := in.Journal.GetEntries(in.Ctx, dateSlotValue[:7])
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntries, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		return in.Response().
+			Speak(h.parseWarning(in) +
+				l.GetTemplated(r.NoEntriesInTimeRangeFound, map[string]interface{}{
+					"TimeRange": readableStringFrom(dateSlotValue, l)}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	var tuples []string
+	for _, entry := range entries {
+		tuples = append(tuples, l.Weekday(entry.EntryDate.Weekday())+", "+entry.EntryDate.String()+": "+spokenTextOf(entry, l))
+	}
+	// TODO: limit response length
+	return in.Response().
+		Speak(h.parseWarning(in) + l.GetTemplated(r.EntriesInTimeRange, map[string]interface{}{
+			"Date": readableStringFrom(dateSlotValue, l), "Entries": strings.Join(tuples, ". "),
+		}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) search(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	query := in.RequestEnv.Request.Intent.Slots["query"].Value
+	entries, e := in.Journal.SearchFor(in.Ctx, query)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.SearchError, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		return in.Response().
+			Speak(l.GetTemplated(r.SearchNoResultsFound, map[string]interface{}{"Query": query}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	text := h.parseWarning(in) + l.GetTemplated(r.SearchResults, map[string]interface{}{"Query": query})
+	for _, entry := range entries {
+		tuple := l.Weekday(entry.EntryDate.Weekday()) + ", " + entry.EntryDate.String() + ": " + strings.TrimRight(spokenTextOf(entry, l), ". ") + ". "
+		if len(text)+len(tuple)+len(l.Get(r.WhatDoYouWantToDoNext)) > responseTextLimit {
+			break
+		}
+		text += tuple
+	}
+	return in.Response().Speak(strings.TrimSpace(text) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+func (h *JournalSkill) exportJournal(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	format := export.HTML
+	if id := resolvedValueID(in.RequestEnv.Request.Intent.Slots["format"]); id != "" {
+		var ok bool
+		format, ok = export.FormatFrom(id)
+		if !ok {
+			panic(errors.Errorf("Invalid export format %v", id))
+		}
+	}
+	entries, e := in.Journal.GetEntries(in.Ctx, "")
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetEntries, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		return in.Response().Speak(l.Get(r.JournalIsEmpty, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	title := spreadsheetNameFor(in.Config, l)
+	content, e := export.Export(entries, format, export.Options{
+		Title:    title,
+		Language: strings.SplitN(in.RequestEnv.Request.Locale, "-", 2)[0],
+	})
+	util.PanicOnError(e)
+	filename := title + format.FileExtension()
+	e = h.fileWriter.WriteFile(in.Ctx, in.accessToken(), in.Config.FolderID, filename, content)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.ExportError, r.ShortPause), e)
+	}
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayExported, map[string]interface{}{"Filename": filename}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
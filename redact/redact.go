@@ -0,0 +1,91 @@
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	alexa "github.com/petergtz/go-alexa"
+)
+
+const Redacted = "<redacted>"
+
+// Redactor removes personal data, i.e. what users dictated and who they are, from everything that gets logged
+// or reported. Slot values and drafts are stripped and user IDs are hashed, so that log lines of the same user
+// can still be correlated. The zero value redacts. Disabled is only meant for debugging.
+//
+// Access tokens are always stripped, because they are credentials.
+type Redactor struct {
+	Disabled bool
+}
+
+// UserID returns a short hash of userID.
+func (r Redactor) UserID(userID string) string {
+	if r.Disabled || userID == "" {
+		return userID
+	}
+	hash := sha256.Sum256([]byte(userID))
+	return "sha256:" + hex.EncodeToString(hash[:8])
+}
+
+// Text strips text the user dictated.
+func (r Redactor) Text(text string) string {
+	if r.Disabled || text == "" {
+		return text
+	}
+	return Redacted
+}
+
+// Request returns a copy of request without slot values.
+func (r Redactor) Request(request *alexa.Request) *alexa.Request {
+	if r.Disabled || request == nil || len(request.Intent.Slots) == 0 {
+		return request
+	}
+	result := *request
+	result.Intent.Slots = make(map[string]alexa.IntentSlot, len(request.Intent.Slots))
+	for name, slot := range request.Intent.Slots {
+		slot.Value = r.Text(slot.Value)
+		result.Intent.Slots[name] = slot
+	}
+	return &result
+}
+
+// Session returns a copy of session without drafts, access token and with a hashed user ID.
+func (r Redactor) Session(session *alexa.Session) *alexa.Session {
+	if session == nil {
+		return nil
+	}
+	result := *session
+	result.User.AccessToken = r.accessToken(session.User.AccessToken)
+	result.User.UserID = r.UserID(session.User.UserID)
+	if !r.Disabled && session.Attributes["drafts"] != nil {
+		result.Attributes = make(map[string]interface{}, len(session.Attributes))
+		for key, value := range session.Attributes {
+			result.Attributes[key] = value
+		}
+		result.Attributes["drafts"] = Redacted
+	}
+	return &result
+}
+
+// SessionAttributes returns attributes without drafts.
+func (r Redactor) SessionAttributes(attributes map[string]interface{}) map[string]interface{} {
+	return r.Session(&alexa.Session{Attributes: attributes}).Attributes
+}
+
+// RequestEnvelope returns a copy of requestEnv redacted like Request and Session do.
+func (r Redactor) RequestEnvelope(requestEnv *alexa.RequestEnvelope) *alexa.RequestEnvelope {
+	if requestEnv == nil {
+		return nil
+	}
+	result := *requestEnv
+	result.Request = r.Request(requestEnv.Request)
+	result.Session = r.Session(requestEnv.Session)
+	return &result
+}
+
+func (r Redactor) accessToken(accessToken string) string {
+	if accessToken == "" {
+		return ""
+	}
+	return Redacted
+}
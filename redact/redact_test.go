@@ -0,0 +1,61 @@
+package redact_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/redact"
+	alexa "github.com/petergtz/go-alexa"
+)
+
+var _ = Describe("Redactor", func() {
+	var requestEnv *alexa.RequestEnvelope
+
+	BeforeEach(func() {
+		requestEnv = &alexa.RequestEnvelope{
+			Request: &alexa.Request{
+				Type:   "IntentRequest",
+				Intent: alexa.Intent{Name: "NewEntryIntent", Slots: map[string]alexa.IntentSlot{"text": {Name: "text", Value: "Dear diary"}}},
+			},
+			Session: &alexa.Session{Attributes: map[string]interface{}{
+				"drafting": true,
+				"drafts":   map[string]interface{}{"2026-10-19": []interface{}{"Dear diary"}},
+			}},
+		}
+		requestEnv.Session.User.UserID = "amzn1.ask.account.some-user"
+		requestEnv.Session.User.AccessToken = "some-token"
+	})
+
+	It("strips slot values, drafts and access token and hashes the user ID", func() {
+		redacted := Redactor{}.RequestEnvelope(requestEnv)
+
+		Expect(redacted.Request.Intent.Slots["text"].Value).To(Equal(Redacted))
+		Expect(redacted.Session.Attributes).To(HaveKeyWithValue("drafts", Redacted))
+		Expect(redacted.Session.Attributes).To(HaveKeyWithValue("drafting", true))
+		Expect(redacted.Session.User.AccessToken).To(Equal(Redacted))
+		Expect(redacted.Session.User.UserID).To(HavePrefix("sha256:"))
+		Expect(redacted.Session.User.UserID).To(Equal(Redactor{}.UserID("amzn1.ask.account.some-user")))
+	})
+
+	It("leaves the original untouched", func() {
+		Redactor{}.RequestEnvelope(requestEnv)
+
+		Expect(requestEnv.Request.Intent.Slots["text"].Value).To(Equal("Dear diary"))
+		Expect(requestEnv.Session.Attributes["drafts"]).NotTo(Equal(Redacted))
+		Expect(requestEnv.Session.User.AccessToken).To(Equal("some-token"))
+		Expect(requestEnv.Session.User.UserID).To(Equal("amzn1.ask.account.some-user"))
+	})
+
+	It("only strips the access token when disabled", func() {
+		redacted := Redactor{Disabled: true}.RequestEnvelope(requestEnv)
+
+		Expect(redacted.Request.Intent.Slots["text"].Value).To(Equal("Dear diary"))
+		Expect(redacted.Session.User.UserID).To(Equal("amzn1.ask.account.some-user"))
+		Expect(redacted.Session.User.AccessToken).To(Equal(Redacted))
+	})
+
+	It("copes with missing parts", func() {
+		Expect(Redactor{}.RequestEnvelope(nil)).To(BeNil())
+		Expect(Redactor{}.RequestEnvelope(&alexa.RequestEnvelope{}).Session).To(BeNil())
+		Expect(Redactor{}.SessionAttributes(nil)).To(BeNil())
+	})
+})
@@ -0,0 +1,24 @@
+package reminders
+
+import "github.com/pkg/errors"
+
+// PermissionMissingError means the user hasn't granted the skill permission to manage reminders.
+type PermissionMissingError struct{ error }
+
+func NewPermissionMissingError(statusCode int, body string) *PermissionMissingError {
+	return &PermissionMissingError{errors.Errorf("PermissionMissingError. status code: %v, body: %v", statusCode, body)}
+}
+func IsPermissionMissingError(e error) bool {
+	_, is := e.(*PermissionMissingError)
+	return is
+}
+
+type ReminderNotFoundError struct{ error }
+
+func NewReminderNotFoundError(body string) *ReminderNotFoundError {
+	return &ReminderNotFoundError{errors.Errorf("ReminderNotFoundError. body: %v", body)}
+}
+func IsReminderNotFoundError(e error) bool {
+	_, is := e.(*ReminderNotFoundError)
+	return is
+}
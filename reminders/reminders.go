@@ -0,0 +1,175 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+	// The Lambda runtime doesn't come with a time zone database.
+	_ "time/tzdata"
+
+	"github.com/petergtz/alexa-journal/tracing"
+	"github.com/pkg/errors"
+)
+
+const dateTimeFormat = "2006-01-02T15:04:05.000"
+
+// Client creates and deletes reminders via the Alexa Reminders API.
+type Client struct {
+	HTTPClient *http.Client
+	Now        func() time.Time
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{HTTPClient: httpClient, Now: time.Now}
+}
+
+type reminderRequest struct {
+	RequestTime      string           `json:"requestTime"`
+	Trigger          trigger          `json:"trigger"`
+	AlertInfo        alertInfo        `json:"alertInfo"`
+	PushNotification pushNotification `json:"pushNotification"`
+}
+
+type trigger struct {
+	Type       string     `json:"type"`
+	TimeZoneID string     `json:"timeZoneId"`
+	Recurrence recurrence `json:"recurrence"`
+}
+
+type recurrence struct {
+	StartDateTime   string   `json:"startDateTime"`
+	RecurrenceRules []string `json:"recurrenceRules"`
+}
+
+type alertInfo struct {
+	SpokenInfo spokenInfo `json:"spokenInfo"`
+}
+
+type spokenInfo struct {
+	Content []content `json:"content"`
+}
+
+type content struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text"`
+}
+
+type pushNotification struct {
+	Status string `json:"status"`
+}
+
+type reminderResponse struct {
+	AlertToken string `json:"alertToken"`
+}
+
+// CreateDailyReminder creates a reminder that recurs every day at hour:minute in the time zone of the device with
+// deviceID and speaks text in the given locale.
+func (c *Client) CreateDailyReminder(ctx context.Context, apiEndpoint string, apiAccessToken string, deviceID string, locale string, text string, hour int, minute int) (alertToken string, err error) {
+	ctx, span := tracing.Start(ctx, "alexa.reminders.create")
+	defer func() { tracing.End(span, err) }()
+	timeZone, e := c.timeZone(ctx, apiEndpoint, apiAccessToken, deviceID)
+	if e != nil {
+		return "", e
+	}
+	// The Reminders API interprets times without offset as local times of the given time zone.
+	now := c.Now().In(timeZone)
+	body, e := json.Marshal(reminderRequest{
+		RequestTime: now.Format(dateTimeFormat),
+		Trigger: trigger{
+			Type:       "SCHEDULED_ABSOLUTE",
+			TimeZoneID: timeZone.String(),
+			Recurrence: recurrence{
+				StartDateTime: now.Format(dateTimeFormat),
+				RecurrenceRules: []string{
+					fmt.Sprintf("FREQ=DAILY;BYHOUR=%v;BYMINUTE=%v;BYSECOND=0;INTERVAL=1;", hour, minute),
+				},
+			},
+		},
+		AlertInfo:        alertInfo{SpokenInfo: spokenInfo{Content: []content{{Locale: locale, Text: text}}}},
+		PushNotification: pushNotification{Status: "ENABLED"},
+	})
+	if e != nil {
+		return "", errors.Wrap(e, "Could not marshal reminder request")
+	}
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, apiEndpoint+"/v1/alerts/reminders", bytes.NewReader(body))
+	if e != nil {
+		return "", errors.Wrap(e, "Could not create reminder request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	respBody, e := c.do(req, apiAccessToken)
+	if e != nil {
+		return "", e
+	}
+	var resp reminderResponse
+	e = json.Unmarshal(respBody, &resp)
+	if e != nil {
+		return "", errors.Wrapf(e, "Could not unmarshal reminder response %v", string(respBody))
+	}
+	return resp.AlertToken, nil
+}
+
+func (c *Client) DeleteReminder(ctx context.Context, apiEndpoint string, apiAccessToken string, alertToken string) (err error) {
+	ctx, span := tracing.Start(ctx, "alexa.reminders.delete")
+	defer func() { tracing.End(span, err) }()
+	req, e := http.NewRequestWithContext(ctx, http.MethodDelete, apiEndpoint+"/v1/alerts/reminders/"+alertToken, nil)
+	if e != nil {
+		return errors.Wrap(e, "Could not create reminder request")
+	}
+	_, e = c.do(req, apiAccessToken)
+	if IsReminderNotFoundError(e) {
+		// The user might have deleted the reminder in the Alexa app already.
+		return nil
+	}
+	return e
+}
+
+// timeZone returns the time zone the device with deviceID is set to, according to the Alexa Settings API.
+func (c *Client) timeZone(ctx context.Context, apiEndpoint string, apiAccessToken string, deviceID string) (*time.Location, error) {
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet,
+		apiEndpoint+"/v2/devices/"+url.PathEscape(deviceID)+"/settings/System.timeZone", nil)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not create time zone request")
+	}
+	respBody, e := c.do(req, apiAccessToken)
+	if e != nil {
+		return nil, e
+	}
+	var timeZoneID string
+	e = json.Unmarshal(respBody, &timeZoneID)
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not unmarshal time zone response %v", string(respBody))
+	}
+	timeZone, e := time.LoadLocation(timeZoneID)
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not load time zone %v", timeZoneID)
+	}
+	return timeZone, nil
+}
+
+func (c *Client) do(req *http.Request, apiAccessToken string) ([]byte, error) {
+	req.Header.Set("Authorization", "Bearer "+apiAccessToken)
+	resp, e := c.HTTPClient.Do(req)
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not send reminder request %v %v", req.Method, req.URL)
+	}
+	defer resp.Body.Close()
+	body, e := ioutil.ReadAll(resp.Body)
+	if e != nil {
+		return nil, errors.Wrap(e, "Could not read reminder response")
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, NewPermissionMissingError(resp.StatusCode, string(body))
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, NewReminderNotFoundError(string(body))
+	case resp.StatusCode >= 300:
+		return nil, errors.Errorf("Unexpected status code %v from %v %v. Body: %v", resp.StatusCode, req.Method, req.URL.Path, string(body))
+	}
+	return body, nil
+}
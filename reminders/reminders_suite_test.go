@@ -0,0 +1,13 @@
+package reminders_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestReminders(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reminders Suite")
+}
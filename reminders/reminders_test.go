@@ -0,0 +1,136 @@
+package reminders_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/reminders"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server         *httptest.Server
+		client         *Client
+		statusCode     int
+		responseBody   string
+		receivedMethod string
+		receivedPath   string
+		receivedAuth   string
+		receivedBody   map[string]interface{}
+		timeZoneStatus int
+	)
+
+	BeforeEach(func() {
+		statusCode = http.StatusOK
+		responseBody = `{"alertToken": "some-alert-token"}`
+		receivedBody = nil
+		receivedMethod = ""
+		timeZoneStatus = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/v2/devices/some-device-id/settings/System.timeZone" {
+				Expect(req.Header.Get("Authorization")).To(Equal("Bearer some-token"))
+				w.WriteHeader(timeZoneStatus)
+				w.Write([]byte(`"Europe/Berlin"`))
+				return
+			}
+			receivedMethod = req.Method
+			receivedPath = req.URL.Path
+			receivedAuth = req.Header.Get("Authorization")
+			body, e := ioutil.ReadAll(req.Body)
+			Expect(e).NotTo(HaveOccurred())
+			if len(body) > 0 {
+				Expect(json.Unmarshal(body, &receivedBody)).To(Succeed())
+			}
+			w.WriteHeader(statusCode)
+			w.Write([]byte(responseBody))
+		}))
+		client = NewClient(server.Client())
+		client.Now = func() time.Time { return time.Date(2020, 3, 1, 10, 30, 0, 0, time.UTC) }
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("CreateDailyReminder", func() {
+		It("creates a daily recurring reminder in the device's time zone and returns its alert token", func() {
+			alertToken, e := client.CreateDailyReminder(context.Background(), server.URL, "some-token", "some-device-id", "en-US", "Time to write in your journal.", 21, 5)
+
+			Expect(e).NotTo(HaveOccurred())
+			Expect(alertToken).To(Equal("some-alert-token"))
+			Expect(receivedMethod).To(Equal("POST"))
+			Expect(receivedPath).To(Equal("/v1/alerts/reminders"))
+			Expect(receivedAuth).To(Equal("Bearer some-token"))
+			Expect(receivedBody).To(HaveKeyWithValue("requestTime", "2020-03-01T11:30:00.000"))
+			Expect(receivedBody).To(HaveKeyWithValue("trigger", map[string]interface{}{
+				"type":       "SCHEDULED_ABSOLUTE",
+				"timeZoneId": "Europe/Berlin",
+				"recurrence": map[string]interface{}{
+					"startDateTime":   "2020-03-01T11:30:00.000",
+					"recurrenceRules": []interface{}{"FREQ=DAILY;BYHOUR=21;BYMINUTE=5;BYSECOND=0;INTERVAL=1;"},
+				},
+			}))
+			Expect(receivedBody).To(HaveKeyWithValue("alertInfo", map[string]interface{}{
+				"spokenInfo": map[string]interface{}{
+					"content": []interface{}{map[string]interface{}{"locale": "en-US", "text": "Time to write in your journal."}},
+				},
+			}))
+			Expect(receivedBody).To(HaveKeyWithValue("pushNotification", map[string]interface{}{"status": "ENABLED"}))
+		})
+
+		It("returns a PermissionMissingError when the user hasn't granted permission", func() {
+			statusCode = http.StatusUnauthorized
+
+			_, e := client.CreateDailyReminder(context.Background(), server.URL, "some-token", "some-device-id", "en-US", "Some text", 21, 0)
+
+			Expect(IsPermissionMissingError(e)).To(BeTrue())
+		})
+
+		It("returns an error on unexpected status codes", func() {
+			statusCode = http.StatusInternalServerError
+
+			_, e := client.CreateDailyReminder(context.Background(), server.URL, "some-token", "some-device-id", "en-US", "Some text", 21, 0)
+
+			Expect(e).To(HaveOccurred())
+			Expect(IsPermissionMissingError(e)).To(BeFalse())
+		})
+
+		It("returns an error when the device's time zone can't be found out", func() {
+			timeZoneStatus = http.StatusInternalServerError
+
+			_, e := client.CreateDailyReminder(context.Background(), server.URL, "some-token", "some-device-id", "en-US", "Some text", 21, 0)
+
+			Expect(e).To(HaveOccurred())
+			Expect(receivedMethod).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteReminder", func() {
+		It("deletes the reminder with the given alert token", func() {
+			responseBody = ""
+
+			Expect(client.DeleteReminder(context.Background(), server.URL, "some-token", "some-alert-token")).To(Succeed())
+			Expect(receivedMethod).To(Equal("DELETE"))
+			Expect(receivedPath).To(Equal("/v1/alerts/reminders/some-alert-token"))
+			Expect(receivedAuth).To(Equal("Bearer some-token"))
+		})
+
+		It("succeeds when the reminder doesn't exist anymore", func() {
+			statusCode = http.StatusNotFound
+
+			Expect(client.DeleteReminder(context.Background(), server.URL, "some-token", "some-alert-token")).To(Succeed())
+		})
+
+		It("returns a PermissionMissingError when the user hasn't granted permission", func() {
+			statusCode = http.StatusForbidden
+
+			Expect(IsPermissionMissingError(client.DeleteReminder(context.Background(), server.URL, "some-token", "some-alert-token"))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package journalskill
+
+import alexa "github.com/petergtz/go-alexa"
+
+// RequestEnvelope extends alexa.RequestEnvelope with the request context, which go-alexa doesn't model yet.
+type RequestEnvelope struct {
+	alexa.RequestEnvelope
+	Context *RequestContext `json:"context"`
+}
+
+type RequestContext struct {
+	System struct {
+		APIEndpoint    string `json:"apiEndpoint"`
+		APIAccessToken string `json:"apiAccessToken"`
+		Device         struct {
+			DeviceID string `json:"deviceId"`
+		} `json:"device"`
+	} `json:"System"`
+}
+
+func (c *RequestContext) apiEndpoint() string {
+	if c == nil {
+		return ""
+	}
+	return c.System.APIEndpoint
+}
+
+func (c *RequestContext) apiAccessToken() string {
+	if c == nil {
+		return ""
+	}
+	return c.System.APIAccessToken
+}
+
+func (c *RequestContext) deviceID() string {
+	if c == nil {
+		return ""
+	}
+	return c.System.Device.DeviceID
+}
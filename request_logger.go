@@ -0,0 +1,19 @@
+package journalskill
+
+import (
+	"github.com/petergtz/alexa-journal/redact"
+	alexa "github.com/petergtz/go-alexa"
+)
+
+// NewRequestLogger logs when a request starts and completes. All log lines written while handling the request carry
+// the request and its session, redacted by redactor.
+func NewRequestLogger(redactor redact.Redactor) RequestInterceptor {
+	return RequestInterceptorFunc(func(in *Input) *alexa.ResponseEnvelope {
+		in.Log = in.Log.With(
+			"request", redactor.Request(in.RequestEnv.Request),
+			"session", redactor.Session(in.RequestEnv.Session))
+		in.Log.Infow("Request started")
+		in.Defer(func() { in.Log.Infow("Request completed") })
+		return nil
+	})
+}
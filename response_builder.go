@@ -0,0 +1,70 @@
+package journalskill
+
+import alexa "github.com/petergtz/go-alexa"
+
+// ResponseBuilder builds a response envelope step by step, e.g.
+//
+//	NewResponse().Speak(text).Reprompt(reprompt).ElicitSlot("date").Build()
+type ResponseBuilder struct {
+	responseEnv *alexa.ResponseEnvelope
+}
+
+func NewResponse() *ResponseBuilder {
+	return &ResponseBuilder{responseEnv: &alexa.ResponseEnvelope{Version: "1.0", Response: &alexa.Response{}}}
+}
+
+func (b *ResponseBuilder) Speak(text string) *ResponseBuilder {
+	b.responseEnv.Response.OutputSpeech = plainText(text)
+	return b
+}
+
+func (b *ResponseBuilder) Reprompt(text string) *ResponseBuilder {
+	b.responseEnv.Response.Reprompt = &alexa.Reprompt{OutputSpeech: plainText(text)}
+	return b
+}
+
+func (b *ResponseBuilder) ElicitSlot(slotName string) *ResponseBuilder {
+	return b.directive(alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: slotName})
+}
+
+func (b *ResponseBuilder) ConfirmSlot(slotName string) *ResponseBuilder {
+	return b.directive(alexa.DialogDirective{Type: "Dialog.ConfirmSlot", SlotToConfirm: slotName})
+}
+
+func (b *ResponseBuilder) ConfirmIntent(intent *alexa.Intent) *ResponseBuilder {
+	return b.directive(alexa.DialogDirective{Type: "Dialog.ConfirmIntent", UpdatedIntent: intent})
+}
+
+func (b *ResponseBuilder) Delegate(intent *alexa.Intent) *ResponseBuilder {
+	return b.directive(alexa.DialogDirective{Type: "Dialog.Delegate", UpdatedIntent: intent})
+}
+
+func (b *ResponseBuilder) directive(directive alexa.DialogDirective) *ResponseBuilder {
+	b.responseEnv.Response.Directives = append(b.responseEnv.Response.Directives, directive)
+	return b
+}
+
+// LinkAccountCard asks the user to link their account in the Alexa app.
+func (b *ResponseBuilder) LinkAccountCard() *ResponseBuilder {
+	b.responseEnv.Response.Card = &alexa.Card{Type: "LinkAccount"}
+	return b
+}
+
+func (b *ResponseBuilder) SimpleCard(title string, content string) *ResponseBuilder {
+	b.responseEnv.Response.Card = &alexa.Card{Type: "Simple", Title: title, Content: content}
+	return b
+}
+
+func (b *ResponseBuilder) EndSession() *ResponseBuilder {
+	b.responseEnv.Response.ShouldSessionEnd = true
+	return b
+}
+
+func (b *ResponseBuilder) SessionAttributes(attributes map[string]interface{}) *ResponseBuilder {
+	b.responseEnv.SessionAttributes = attributes
+	return b
+}
+
+func (b *ResponseBuilder) Build() *alexa.ResponseEnvelope {
+	return b.responseEnv
+}
@@ -9,7 +9,7 @@ pushd cmd/skill
     GOOS=linux go build -o main
 
     rm -f $zip_file
-    zip $zip_file main
+    zip $zip_file main config.toml
 
     aws s3 cp $zip_file s3://alexa-golang-skills/$zip_file
     aws s3 cp s3://alexa-golang-skills/$zip_file s3://alexa-golang-skills-eu-west-1/$zip_file &
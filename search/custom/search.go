@@ -1,19 +1,27 @@
 package custom
 
 import (
+	"context"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/metrics"
+	"github.com/petergtz/alexa-journal/tracing"
+	"github.com/pkg/errors"
 	"github.com/pkg/math"
 )
 
 type SearchIndex struct {
 	Index map[string][]string
 	Log   *zap.SugaredLogger
+	// Metrics, if set, records the latency of every search.
+	Metrics metrics.Metrics
 }
 
 func NewSearchIndex(log *zap.SugaredLogger) *SearchIndex {
@@ -30,21 +38,32 @@ func (si *SearchIndex) Add(id string, text string) {
 	}
 }
 
-func (si *SearchIndex) Search(query string) []journal.Rank {
+// Search ranks the indexed ids by how well their texts match query. Matching is fuzzy and can take a while
+// on large journals, so Search gives up with ctx's error when ctx is done.
+func (si *SearchIndex) Search(ctx context.Context, query string) (ranks []journal.Rank, err error) {
+	ctx, span := tracing.Start(ctx, "search", attribute.Int("index-size", len(si.Index)))
+	defer func(start time.Time) {
+		tracing.End(span, err)
+		metrics.Measure(si.Metrics, "search", start, err, nil)
+	}(time.Now())
+
 	wordResults := make(map[string]map[string]float32)
 	for _, word := range wordsIn(query) {
+		if e := ctx.Err(); e != nil {
+			return nil, errors.Wrap(e, "Search aborted")
+		}
 		word = strings.ToLower(word)
 		wordResults[word] = make(map[string]float32)
 		closestWords := closestMatches(word, keysAsSlice(si.Index), 0.75)
 
-		si.Log.Debugw("Closest matches", "word", word, "closest-matches", closestWords)
+		si.Log.Debugw("Closest matches", "num-closest-matches", len(closestWords))
 		for _, closestWord := range closestWords {
 			for _, id := range si.Index[strings.ToLower(closestWord.Result)] {
 				wordResults[word][id] = closestWord.Confidence
 			}
 		}
 	}
-	si.Log.Debugw("word results", "wordResults", wordResults)
+	si.Log.Debugw("word results", "num-words", len(wordResults))
 
 	result := make(map[string]float32)
 	for _, wordResult := range wordResults {
@@ -54,7 +73,7 @@ func (si *SearchIndex) Search(query string) []journal.Rank {
 	}
 	resultSlice := rankSliceFrom(result)
 	sort.Slice(resultSlice, func(i int, j int) bool { return resultSlice[i].Confidence > resultSlice[j].Confidence })
-	return topRanks(resultSlice, 0.75)
+	return topRanks(resultSlice, 0.75), nil
 }
 
 func wordsIn(text string) []string {
@@ -64,7 +83,7 @@ func wordsIn(text string) []string {
 func closestMatches(word string, targets []string, cutOffConfidence float32) []journal.Rank {
 	var ranks []journal.Rank
 	for _, target := range targets {
-		ranks = append(ranks, journal.Rank{target, 1.0 - float32(math.Min(len(word), LevenshteinDistance(word, target)))/float32(len(word))})
+		ranks = append(ranks, journal.Rank{Result: target, Confidence: 1.0 - float32(math.Min(len(word), LevenshteinDistance(word, target)))/float32(len(word))})
 	}
 	sort.Slice(ranks, func(i int, j int) bool { return ranks[i].Confidence > ranks[j].Confidence })
 	return topRanks(ranks, cutOffConfidence)
@@ -84,7 +103,7 @@ func rankSliceFrom(m map[string]float32) []journal.Rank {
 	resultSlice := make([]journal.Rank, len(m))
 	u := 0
 	for id, confidence := range m {
-		resultSlice[u] = journal.Rank{id, confidence}
+		resultSlice[u] = journal.Rank{Result: id, Confidence: confidence}
 		u++
 	}
 	return resultSlice
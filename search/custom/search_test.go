@@ -1,6 +1,7 @@
 package custom_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -33,7 +34,8 @@ var _ = Describe("CustomIndex", func() {
 			index.Add(parts[1], parts[2])
 		}
 
-		hits := index.Search("Dampfmaschine")
+		hits, e := index.Search(context.Background(), "Dampfmaschine")
+		Expect(e).NotTo(HaveOccurred())
 		for _, line := range strings.Split(string(b), "\n") {
 			parts := strings.Split(line, "\t")
 			if len(parts) != 3 {
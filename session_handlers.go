@@ -0,0 +1,39 @@
+package journalskill
+
+import (
+	"context"
+
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	alexa "github.com/petergtz/go-alexa"
+)
+
+func (h *JournalSkill) launch(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if in.Config.ReadOnThisDayOnLaunch {
+		defer h.speakWhileSlow(in)()
+		if onThisDay, ok := h.onThisDayGreeting(in.Ctx, in.accessToken(), h.journalLocationFor(in.Config, l), l, in.Log); ok {
+			return in.Response().Speak(l.Get(r.YourJournalIsNowOpenWithoutQuestion, r.LongPause) + onThisDay +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+		}
+	} else {
+		// cache warming. It must outlive this request, so it doesn't use the request's ctx:
+		go func(accessToken string, location JournalLocation) {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			h.journalProvider.Get(ctx, accessToken, location)
+		}(in.accessToken(), h.journalLocationFor(in.Config, l))
+	}
+	return in.Response().Speak(l.Get(r.YourJournalIsNowOpen)).Build()
+}
+
+func (h *JournalSkill) help(in *Input) *alexa.ResponseEnvelope {
+	return in.Response().Speak(in.Localizer.Get(r.Help)).Build()
+}
+
+func (h *JournalSkill) stop(in *Input) *alexa.ResponseEnvelope {
+	return in.Response().EndSession().Build()
+}
+
+func (h *JournalSkill) sessionEnded(in *Input) *alexa.ResponseEnvelope {
+	return &alexa.ResponseEnvelope{Version: "1.0"}
+}
@@ -0,0 +1,183 @@
+package journalskill
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/petergtz/alexa-journal/locale"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	"github.com/petergtz/alexa-journal/reminders"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/pkg/errors"
+)
+
+func (h *JournalSkill) beSuccinct(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.BeSuccinct = true
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayWillBeSuccinct, r.WhatDoYouWantToDoNext)).
+		Reprompt(in.Localizer.Get(r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) beVerbose(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.BeSuccinct = false
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayWillBeVerbose, r.WhatDoYouWantToDoNext)).
+		Reprompt(in.Localizer.Get(r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) enableOnThisDayGreeting(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.ReadOnThisDayOnLaunch = true
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayOnThisDayGreetingEnabled, r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) disableOnThisDayGreeting(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.ReadOnThisDayOnLaunch = false
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayOnThisDayGreetingDisabled, r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) enableMoodQuestion(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.AskForMood = true
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayMoodQuestionEnabled, r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) disableMoodQuestion(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.AskForMood = false
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.ResponseWithSession().
+		Speak(in.Localizer.Get(r.OkayMoodQuestionDisabled, r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) choosePromptSet(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	promptSet := resolvedValueID(in.RequestEnv.Request.Intent.Slots["promptSet"])
+	if l.Prompt(promptSet, 0) == "" {
+		return in.Response().Speak(l.Get(r.UnknownPromptSet)).Build()
+	}
+	newConfig := in.Config
+	newConfig.PromptSet = promptSet
+	newConfig.NextPromptIndex = 0
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayPromptSetChosen, map[string]interface{}{"PromptSet": l.PromptSetName(promptSet)}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) disablePrompts(in *Input) *alexa.ResponseEnvelope {
+	newConfig := in.Config
+	newConfig.PromptSet = ""
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().Speak(in.Localizer.Get(r.OkayPromptsDisabled, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+func (h *JournalSkill) setReminder(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	hour, minute, ok := hourAndMinuteFrom(in.RequestEnv.Request.Intent.Slots["time"].Value)
+	if !ok {
+		return in.Response().Speak(l.Get(r.InvalidReminderTime)).Build()
+	}
+	if in.Config.DailyReminderAlertToken != "" {
+		e := h.remindersClient.DeleteReminder(in.Ctx, in.RequestContext.apiEndpoint(), in.RequestContext.apiAccessToken(), in.Config.DailyReminderAlertToken)
+		if e != nil && !reminders.IsPermissionMissingError(errors.Cause(e)) {
+			in.Log.Errorw("Could not delete previous reminder", "error", e)
+		}
+	}
+	alertToken, e := h.remindersClient.CreateDailyReminder(in.Ctx, in.RequestContext.apiEndpoint(), in.RequestContext.apiAccessToken(),
+		in.RequestContext.deviceID(), in.RequestEnv.Request.Locale, l.Get(r.ReminderText), hour, minute)
+	if e != nil {
+		return h.reminderErrorResponse(in, e)
+	}
+	newConfig := in.Config
+	newConfig.DailyReminderAlertToken = alertToken
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().
+		Speak(l.GetTemplated(r.OkayReminderSet, map[string]interface{}{"Time": fmt.Sprintf("%d:%02d", hour, minute)}) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) cancelReminder(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if in.Config.DailyReminderAlertToken == "" {
+		return in.Response().Speak(l.Get(r.NoReminderToCancel, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+	}
+	e := h.remindersClient.DeleteReminder(in.Ctx, in.RequestContext.apiEndpoint(), in.RequestContext.apiAccessToken(), in.Config.DailyReminderAlertToken)
+	if e != nil {
+		return h.reminderErrorResponse(in, e)
+	}
+	newConfig := in.Config
+	newConfig.DailyReminderAlertToken = ""
+	h.configService.PersistConfig(in.Ctx, in.userID(), newConfig)
+	return in.Response().Speak(l.Get(r.OkayReminderCancelled, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+func (h *JournalSkill) reminderErrorResponse(in *Input, e error) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	if reminders.IsPermissionMissingError(errors.Cause(e)) {
+		return in.Response().
+			Speak(l.Get(r.RemindersPermissionMissing)).
+			SimpleCard(l.Get(r.Journal), l.Get(r.RemindersPermissionCard)).
+			EndSession().
+			Build()
+	}
+	in.Log.Errorw("Error while accessing reminders", "error", e)
+	h.errorReporter.ReportError(in.Ctx, e)
+	return in.Response().Speak(l.Get(r.ReminderError, r.LongPause, r.WhatDoYouWantToDoNext)).Build()
+}
+
+var reminderTimeRegex = regexp.MustCompile(`^(\d{2}):(\d{2})$`)
+
+// hourAndMinuteFrom parses an AMAZON.TIME slot value. Vague times like "evening" are mapped to a fixed time of day.
+func hourAndMinuteFrom(timeValue string) (hour int, minute int, ok bool) {
+	switch timeValue {
+	case "MO":
+		return 9, 0, true
+	case "AF":
+		return 14, 0, true
+	case "EV":
+		return 19, 0, true
+	case "NI":
+		return 21, 0, true
+	}
+	groups := reminderTimeRegex.FindStringSubmatch(timeValue)
+	if groups == nil {
+		return 0, 0, false
+	}
+	hour, _ = strconv.Atoi(groups[1])
+	minute, _ = strconv.Atoi(groups[2])
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+func (h *JournalSkill) succinctModeExplanation(ctx context.Context, userID string, config Config, l *locale.Localizer) string {
+	if config.ShouldExplainAboutSuccinctMode {
+		config.ShouldExplainAboutSuccinctMode = false
+		h.configService.PersistConfig(ctx, userID, config)
+		return l.Get(r.SuccinctModeExplanation)
+	}
+	return ""
+}
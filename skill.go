@@ -1,17 +1,21 @@
 package journalskill
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"math/rand"
 	"regexp"
 	"strconv"
 	"time"
 
-	"github.com/mitchellh/mapstructure"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/petergtz/alexa-journal/locale"
 	"github.com/petergtz/alexa-journal/locale/resources"
 	r "github.com/petergtz/alexa-journal/locale/resources"
+	"github.com/petergtz/alexa-journal/redact"
+	"github.com/petergtz/alexa-journal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/petergtz/alexa-journal/util"
 	"github.com/rickb777/date"
@@ -29,7 +33,45 @@ import (
 const responseTextLimit = 8000
 
 type JournalProvider interface {
-	Get(accessToken string, spreadsheetName string) (j.Journal, error)
+	Get(ctx context.Context, accessToken string, location JournalLocation) (j.Journal, error)
+	// FolderID returns the ID of the folder with the given name, or an empty string if there is none.
+	FolderID(ctx context.Context, accessToken string, folderName string) (string, error)
+}
+
+// JournalLocation tells a JournalProvider which spreadsheet holds a journal.
+type JournalLocation struct {
+	// SpreadsheetName is how the spreadsheet is found and what a new one is called. It's also the title of the sheet
+	// inside the spreadsheet.
+	SpreadsheetName string
+	// SpreadsheetID, if set, is the spreadsheet the user chose. It makes the search by name unnecessary.
+	SpreadsheetID string
+	// FolderID, if set, restricts the search by name to this folder. New spreadsheets are created in it.
+	FolderID string
+	// AlternativeNames are the names the spreadsheet has in the other languages of the skill. If there is no
+	// spreadsheet named SpreadsheetName, one with an alternative name is used instead of creating a new one.
+	AlternativeNames []string
+}
+
+// JournalFile is a spreadsheet a journal might be in.
+type JournalFile struct {
+	ID           string
+	Name         string
+	ModifiedTime time.Time
+}
+
+// AmbiguousJournalError is returned by JournalProviders that found several spreadsheets with a journal's name, so that
+// the user can choose one of them.
+type AmbiguousJournalError interface {
+	error
+	Candidates() []JournalFile
+}
+
+// SplitJournalError is returned by JournalProviders that found entries of a journal under several of its names, e.g.
+// because the user switched the language of their device, so that the user can merge them.
+type SplitJournalError interface {
+	error
+	// Parts returns the spreadsheets, the one named SpreadsheetName first.
+	Parts() []JournalFile
 }
 
 type Localizer interface {
@@ -39,631 +81,412 @@ type Localizer interface {
 }
 
 type ErrorInterpreter interface {
-	Interpret(error, Localizer) string
+	Interpret(context.Context, error, Localizer) string
+	// RequiresAccountLinking tells whether the error can only be resolved by linking the account again.
+	RequiresAccountLinking(error) bool
+	// IsNotFound tells whether the error means that a spreadsheet doesn't exist anymore or isn't accessible anymore.
+	IsNotFound(error) bool
 }
 
 type ErrorReporter interface {
-	ReportPanic(e interface{}, requestEnv *alexa.RequestEnvelope)
-	ReportError(e error)
+	// ReportPanic and ReportError take the context of the request the error occurred in, so that reports can
+	// refer to its trace.
+	ReportPanic(ctx context.Context, e interface{}, requestEnv *alexa.RequestEnvelope)
+	ReportError(ctx context.Context, e error)
+}
+type RemindersClient interface {
+	// CreateDailyReminder creates a reminder at hour:minute in the time zone of the device with deviceID.
+	CreateDailyReminder(ctx context.Context, apiEndpoint string, apiAccessToken string, deviceID string, locale string, text string, hour int, minute int) (alertToken string, err error)
+	DeleteReminder(ctx context.Context, apiEndpoint string, apiAccessToken string, alertToken string) error
+}
+
+type ProgressiveResponder interface {
+	Speak(ctx context.Context, apiEndpoint string, apiAccessToken string, requestID string, speech string) error
+}
+
+type FileWriter interface {
+	// WriteFile writes content to the file with filename in the folder with folderID, or anywhere in the user's Drive
+	// if folderID is empty.
+	WriteFile(ctx context.Context, accessToken string, folderID string, filename string, content string) error
 }
+
 type JournalSkill struct {
-	journalProvider  JournalProvider
-	errorInterpreter ErrorInterpreter
-	log              *zap.SugaredLogger
-	errorReporter    ErrorReporter
-	i18nBundle       *i18n.Bundle
-	configService    ConfigService
+	journalProvider      JournalProvider
+	errorInterpreter     ErrorInterpreter
+	log                  *zap.SugaredLogger
+	errorReporter        ErrorReporter
+	i18nBundle           *i18n.Bundle
+	configService        ConfigService
+	remindersClient      RemindersClient
+	fileWriter           FileWriter
+	progressiveResponder ProgressiveResponder
+	requestInterceptors  []RequestInterceptor
+	coreInterceptors     []RequestInterceptor
+	handlers             []RequestHandler
+	responseInterceptors []ResponseInterceptor
+
+	progressiveResponseThreshold time.Duration
 }
 
 type ConfigService interface {
-	GetConfig(userID string) Config
-	PersistConfig(userID string, config Config)
+	GetConfig(ctx context.Context, userID string) Config
+	PersistConfig(ctx context.Context, userID string, config Config)
 }
 
 type Config struct {
 	BeSuccinct                     bool
 	ShouldExplainAboutSuccinctMode bool
+	// ReadOnThisDayOnLaunch makes the skill read entries from this day in previous years when the journal is opened.
+	ReadOnThisDayOnLaunch bool
+	// AskForMood makes the skill ask the user how their day was after saving an entry.
+	AskForMood bool
+	// RecentMemoryIDs are the IDs of the entries served as random memories most recently, oldest first.
+	RecentMemoryIDs []string
+	// DailyReminderAlertToken identifies the user's daily journaling reminder in the Alexa Reminders API.
+	DailyReminderAlertToken string
+	// PromptSet is the set of guided journaling prompts new entries start with. Empty means no prompts.
+	PromptSet string
+	// NextPromptIndex is the index of the next prompt in PromptSet, so that prompts rotate across entries.
+	NextPromptIndex int
+	// Journals are the names of the journals the user created besides their main journal.
+	Journals []string
+	// ActiveJournal is the name of the journal intents operate on. Empty means the main journal.
+	ActiveJournal string
+	// SpreadsheetIDs are the spreadsheets of the user's journals, keyed by journal name. The main journal's key is
+	// mainJournalID. A journal's spreadsheet is remembered when it's first found, so that it doesn't depend on the
+	// spreadsheet name, which differs between languages.
+	SpreadsheetIDs map[string]string
+	// FolderID is the Drive folder the user keeps their journals in. Empty means anywhere in their Drive. Journals
+	// with a spreadsheet in SpreadsheetIDs are used from wherever that spreadsheet is.
+	FolderID string
+	// FolderName is the name of the folder with FolderID.
+	FolderName string
 }
 
+const maxRecentMemories = 20
+
+// defaultProgressiveResponseThreshold is how long the user may hear nothing before the skill tells them it's still
+// busy.
+const defaultProgressiveResponseThreshold = 1500 * time.Millisecond
+
+// requestTimeout bounds the time spent on Google API calls per request. Alexa gives up on a skill after
+// 8 seconds, so this leaves enough time to still tell the user that something went wrong.
+const requestTimeout = 6 * time.Second
+
+// gratitudeListLength is the number of items the user is asked for in a gratitude list.
+const gratitudeListLength = 3
+
 func NewJournalSkill(journalProvider JournalProvider,
 	errorInterpreter ErrorInterpreter,
 	log *zap.SugaredLogger,
 	errorReporter ErrorReporter,
 	i18nBundle *i18n.Bundle,
 	configService ConfigService,
+	remindersClient RemindersClient,
+	fileWriter FileWriter,
+	progressiveResponder ProgressiveResponder,
 ) *JournalSkill {
-	return &JournalSkill{
-		journalProvider:  journalProvider,
-		errorInterpreter: errorInterpreter,
-		log:              log,
-		errorReporter:    errorReporter,
-		configService:    configService,
-		i18nBundle:       i18nBundle,
+	h := &JournalSkill{
+		journalProvider:      journalProvider,
+		errorInterpreter:     errorInterpreter,
+		log:                  log,
+		errorReporter:        errorReporter,
+		configService:        configService,
+		i18nBundle:           i18nBundle,
+		remindersClient:      remindersClient,
+		fileWriter:           fileWriter,
+		progressiveResponder: progressiveResponder,
+
+		progressiveResponseThreshold: defaultProgressiveResponseThreshold,
+	}
+	h.requestInterceptors = []RequestInterceptor{NewRequestLogger(redact.Redactor{})}
+	h.coreInterceptors = []RequestInterceptor{
+		RequestInterceptorFunc(h.requireAccessToken),
+		RequestInterceptorFunc(h.loadConfig),
+		RequestInterceptorFunc(h.speakWhileSlowForIntents),
+		RequestInterceptorFunc(decodeSession),
+		RequestInterceptorFunc(routeBareNumbers),
+		RequestInterceptorFunc(h.loadJournal),
 	}
+	h.handlers = []RequestHandler{
+		forRequestType("LaunchRequest", h.launch),
+		forRequestType("SessionEndedRequest", h.sessionEnded),
+		forIntents(h.beSuccinct, "BeSuccinctIntent"),
+		forIntents(h.beVerbose, "BeVerboseIntent"),
+		forIntents(h.enableOnThisDayGreeting, "EnableOnThisDayGreetingIntent"),
+		forIntents(h.disableOnThisDayGreeting, "DisableOnThisDayGreetingIntent"),
+		forIntents(h.enableMoodQuestion, "EnableMoodQuestionIntent"),
+		forIntents(h.disableMoodQuestion, "DisableMoodQuestionIntent"),
+		forIntents(h.onThisDay, "OnThisDayIntent"),
+		forIntents(h.randomMemory, "RandomMemoryIntent"),
+		forIntents(h.newEntry, "NewEntryIntent"),
+		forIntents(h.gratitudeList, "GratitudeListIntent"),
+		forIntents(h.listAllEntriesInDate, "ListAllEntriesInDate"),
+		forIntents(h.readEntryAbsoluteDate, "ReadAllEntriesInDate", "ReadExistingEntryAbsoluteDateIntent"),
+		forIntents(h.readEntryRelativeDate, "ReadExistingEntryRelativeDateIntent"),
+		forIntents(h.search, "SearchIntent"),
+		forIntents(h.deleteEntry, "DeleteEntryIntent"),
+		forIntents(h.rateMood, "RateMoodIntent"),
+		forIntents(h.averageMood, "AverageMoodIntent"),
+		forIntents(h.happiestDay, "HappiestDayIntent"),
+		forIntents(h.statistics, "StatisticsIntent"),
+		forIntents(h.choosePromptSet, "ChoosePromptSetIntent"),
+		forIntents(h.disablePrompts, "DisablePromptsIntent"),
+		forIntents(h.exportJournal, "ExportJournalIntent"),
+		forIntents(h.listJournals, "ListJournalsIntent"),
+		forIntents(h.createJournal, "CreateJournalIntent"),
+		forIntents(h.switchJournal, "SwitchJournalIntent"),
+		forIntents(h.chooseJournalFile, "ChooseJournalFileIntent"),
+		forIntents(h.useJournalFolder, "UseJournalFolderIntent"),
+		forIntents(h.mergeJournalFiles, "AMAZON.YesIntent"),
+		forIntents(h.keepJournalFilesSeparate, "AMAZON.NoIntent"),
+		forIntents(h.setReminder, "SetReminderIntent"),
+		forIntents(h.cancelReminder, "CancelReminderIntent"),
+		forIntents(h.help, "AMAZON.HelpIntent"),
+		forIntents(h.stop, "AMAZON.CancelIntent", "AMAZON.StopIntent"),
+	}
+	return h
+}
+
+// UseRequestInterceptors replaces the configurable request interceptors, which by default only consist of a
+// redacting request logger. They run in the given order before the core interceptors, i.e. before the access token
+// is checked and config, journal and session are loaded.
+func (h *JournalSkill) UseRequestInterceptors(interceptors ...RequestInterceptor) *JournalSkill {
+	h.requestInterceptors = interceptors
+	return h
+}
+
+// UseResponseInterceptors replaces the response interceptors. They run in the given order.
+func (h *JournalSkill) UseResponseInterceptors(interceptors ...ResponseInterceptor) *JournalSkill {
+	h.responseInterceptors = interceptors
+	return h
+}
+
+// UseProgressiveResponseThreshold replaces how long the user may hear nothing before the skill tells them it's still
+// busy.
+func (h *JournalSkill) UseProgressiveResponseThreshold(threshold time.Duration) *JournalSkill {
+	h.progressiveResponseThreshold = threshold
+	return h
 }
 
 type SessionAttributes struct {
-	Drafts   map[string][]string `json:"drafts"`
-	Drafting bool                `json:"drafting"`
+	Drafts           map[string][]string `json:"drafts"`
+	Drafting         bool                `json:"drafting"`
+	EntryIDsToDelete []string            `json:"entryIDsToDelete,omitempty"`
+	// EntryIDAwaitingMood is the ID of the entry saved last, which the user can still rate.
+	EntryIDAwaitingMood string `json:"entryIDAwaitingMood,omitempty"`
+	// Prompts are the guided journaling prompts the drafts were started with, keyed by date like Drafts.
+	Prompts map[string]string `json:"prompts,omitempty"`
+	// JournalFileCandidates are the IDs of the spreadsheets the user was asked to choose from for the active journal.
+	JournalFileCandidates []string `json:"journalFileCandidates,omitempty"`
+	// JournalFilesToMerge are the IDs of the spreadsheets the user was asked to merge. Entries go into the first one.
+	JournalFilesToMerge []string `json:"journalFilesToMerge,omitempty"`
+	// MergeTargetName is the name of the first of JournalFilesToMerge.
+	MergeTargetName string `json:"mergeTargetName,omitempty"`
+}
+
+func (h *JournalSkill) ProcessRequest(requestEnv *alexa.RequestEnvelope) *alexa.ResponseEnvelope {
+	return h.ProcessRequestWithContext(requestEnv, nil)
 }
 
-func (h *JournalSkill) ProcessRequest(requestEnv *alexa.RequestEnvelope) (responseEnv *alexa.ResponseEnvelope) {
+// ProcessRequestWithContext processes the request like ProcessRequest, but additionally makes the request context
+// available, which is needed to call Alexa APIs such as the Reminders API.
+func (h *JournalSkill) ProcessRequestWithContext(requestEnv *alexa.RequestEnvelope, requestContext *RequestContext) (responseEnv *alexa.ResponseEnvelope) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	in := &Input{Ctx: ctx, Start: time.Now(), RequestEnv: requestEnv, RequestContext: requestContext, Log: h.log}
+	in.Ctx, in.span = tracing.Start(ctx, "ProcessRequest",
+		attribute.String("intent", in.intentName()),
+		attribute.String("request-id", requestEnv.Request.RequestID))
+	if traceID := tracing.TraceID(in.Ctx); traceID != "" {
+		in.Log = in.Log.With("trace-id", traceID)
+	}
 	defer func() {
 		if e := recover(); e != nil {
-			h.errorReporter.ReportPanic(e, requestEnv)
+			h.errorReporter.ReportPanic(in.Ctx, e, requestEnv)
 			responseEnv = internalError(i18n.NewLocalizer(h.i18nBundle, requestEnv.Request.Locale))
+			in.span.SetStatus(codes.Error, "panic")
 		}
+		in.span.End()
 	}()
+	defer in.runDeferred()
 
-	log := h.log.With("request", requestEnv.Request, "session", requestEnv.Session)
-	log.Infow("Request started")
-	defer log.Infow("Request completed")
-
-	if requestEnv.Session.User.AccessToken == "" {
-		return &alexa.ResponseEnvelope{Version: "1.0",
-			Response: &alexa.Response{
-				OutputSpeech: plainText(i18n.NewLocalizer(h.i18nBundle, requestEnv.Request.Locale).
-					MustLocalize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: r.LinkWithGoogleAccount.String()}})),
-				Card:             &alexa.Card{Type: "LinkAccount"},
-				ShouldSessionEnd: true,
-			},
-			SessionAttributes: requestEnv.Session.Attributes,
-		}
+	responseEnv = h.handle(in)
+	for _, interceptor := range h.responseInterceptors {
+		interceptor.InterceptResponse(in, responseEnv)
 	}
-	config := h.configService.GetConfig(requestEnv.Session.User.UserID)
-	l := locale.NewLocalizer(
-		h.i18nBundle,
-		requestEnv.Request.Locale,
-		config.BeSuccinct)
-
-	switch requestEnv.Request.Type {
-
-	case "LaunchRequest":
-		// cache warming:
-		go h.journalProvider.Get(requestEnv.Session.User.AccessToken, l.Get(r.Journal))
-
-		return &alexa.ResponseEnvelope{Version: "1.0",
-			Response:          &alexa.Response{OutputSpeech: plainText(l.Get(r.YourJournalIsNowOpen))},
-			SessionAttributes: requestEnv.Session.Attributes,
-		}
-
-	case "IntentRequest":
-		journal, e := h.journalProvider.Get(requestEnv.Session.User.AccessToken, l.Get(r.Journal))
-		if e != nil {
-			log.Errorw("Error while getting journal via journalProvider", "error", e)
-			return plainTextRespEnv(h.errorInterpreter.Interpret(e, l), requestEnv.Session.Attributes)
-		}
-		log.Debugw("Journal downloaded")
-
-		var sessionAttributes SessionAttributes
-		sessionAttributes.Drafts = make(map[string][]string)
-		e = mapstructure.Decode(requestEnv.Session.Attributes, &sessionAttributes)
-		util.PanicOnError(errors.Wrap(e, "Could not parse sessionAttributes"))
-
-		intent := requestEnv.Request.Intent
-		switch intent.Name {
-		case "BeSuccinctIntent":
-			newConfig := config
-			newConfig.BeSuccinct = true
-			h.configService.PersistConfig(requestEnv.Session.User.UserID, newConfig)
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response: &alexa.Response{
-					OutputSpeech: plainText(l.Get(r.OkayWillBeSuccinct, r.WhatDoYouWantToDoNext)),
-					Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.WhatDoYouWantToDoNext))},
-				},
-				SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-			}
-		case "BeVerboseIntent":
-			newConfig := config
-			newConfig.BeSuccinct = false
-			h.configService.PersistConfig(requestEnv.Session.User.UserID, newConfig)
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response: &alexa.Response{
-					OutputSpeech: plainText(l.Get(r.OkayWillBeVerbose, r.WhatDoYouWantToDoNext)),
-					Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.WhatDoYouWantToDoNext))},
-				},
-				SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-			}
-		case "NewEntryIntent":
-			switch requestEnv.Request.DialogState {
-			case "STARTED":
-				return pureDelegate(&intent, requestEnv.Session.Attributes)
-			case "IN_PROGRESS":
-				switch intent.ConfirmationStatus {
-				case "NONE":
-					if intent.Slots["date"].Value == "" {
-						return pureDelegate(&intent, requestEnv.Session.Attributes)
-					}
-					_, _, dateType := DateFrom(intent.Slots["date"].Value)
-					if dateType != DayDate {
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								Directives: []interface{}{
-									alexa.DialogDirective{
-										Type:         "Dialog.ElicitSlot",
-										SlotToElicit: "date",
-									},
-								},
-								OutputSpeech: plainText(l.Get(r.InvalidDate)),
-							},
-							SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-						}
-					}
-					// TODO: could we use intent.Slots["text"].Value == "" instead of !sessionAttributes.Drafting?
-					if _, exists := sessionAttributes.Drafts[intent.Slots["date"].Value]; exists && !sessionAttributes.Drafting {
-						switch intent.Slots["text"].ConfirmationStatus {
-						case "NONE":
-							outputSpeech := plainText(l.GetTemplated(r.NewEntryDraftExists, map[string]interface{}{
-								"Draft": strings.Join(sessionAttributes.Drafts[intent.Slots["date"].Value], ". "),
-							}))
-							return &alexa.ResponseEnvelope{Version: "1.0",
-								Response: &alexa.Response{
-									OutputSpeech: outputSpeech,
-									Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ConfirmSlot", SlotToConfirm: "text"}},
-									Reprompt:     &alexa.Reprompt{OutputSpeech: outputSpeech},
-								},
-								SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-							}
-						case "CONFIRMED":
-							break
-						case "DENIED":
-							delete(sessionAttributes.Drafts, intent.Slots["date"].Value)
-						}
-					}
-					switch strings.ToLower(intent.Slots["text"].Value) {
-					case "":
-						sessionAttributes.Drafting = true
-						dateString := ""
-						if intent.Slots["date"].Value != "" {
-							dateString = l.GetTemplated(r.ForDate,
-								map[string]interface{}{"Date": intent.Slots["date"].Value})
-						}
-
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-
-								OutputSpeech: plainText(l.GetTemplated(r.YouCanNowCreateYourEntry, map[string]interface{}{"ForDate": dateString})),
-								Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-								Reprompt: &alexa.Reprompt{
-									OutputSpeech: plainText(l.GetTemplated(r.YouCanNowCreateYourEntry_succinct, map[string]interface{}{"ForDate": dateString})),
-								},
-							},
-							SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-						}
-					case l.Get(r.Repeat1), l.Get(r.Repeat2):
-						if len(sessionAttributes.Drafts[intent.Slots["date"].Value]) == 0 {
-							return &alexa.ResponseEnvelope{Version: "1.0",
-								Response: &alexa.Response{
-									OutputSpeech: plainText(l.Get(r.YourEntryIsEmptyNoRepeat)),
-									Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-								},
-								SessionAttributes: requestEnv.Session.Attributes,
-							}
-						}
-
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								OutputSpeech: plainText(l.GetTemplated(r.IRepeat, map[string]interface{}{
-									"Text": sessionAttributes.Drafts[intent.Slots["date"].Value][len(sessionAttributes.Drafts[intent.Slots["date"].Value])-1]})),
-								Directives: []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-							},
-							SessionAttributes: requestEnv.Session.Attributes,
-						}
-					case l.Get(r.Correct1), l.Get(r.Correct2):
-						if len(sessionAttributes.Drafts[intent.Slots["date"].Value]) == 0 {
-							return &alexa.ResponseEnvelope{Version: "1.0",
-								Response: &alexa.Response{
-									OutputSpeech: plainText(l.Get(r.YourEntryIsEmptyNoCorrect)),
-									Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-								},
-								SessionAttributes: requestEnv.Session.Attributes,
-							}
-						}
-						sessionAttributes.Drafts[intent.Slots["date"].Value] = sessionAttributes.Drafts[intent.Slots["date"].Value][:len(sessionAttributes.Drafts[intent.Slots["date"].Value])-1]
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								OutputSpeech: plainText(l.Get(r.OkayCorrectPart)),
-								Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-								Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.CorrectPartReprompt))},
-							},
-							SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-						}
-					case l.Get(r.Abort):
-						sessionAttributes.Drafting = false
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								OutputSpeech: plainText(l.Get(r.NewEntryAborted, r.LongPause) +
-									h.succinctModeExplanation(requestEnv.Session.User.UserID, config, l) +
-									l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-							},
-							SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-						}
-					case l.Get(r.Done):
-						if len(sessionAttributes.Drafts[intent.Slots["date"].Value]) == 0 {
-							sessionAttributes.Drafting = false
-							return &alexa.ResponseEnvelope{Version: "1.0",
-								Response: &alexa.Response{OutputSpeech: plainText(l.Get(r.YourEntryIsEmptyNoSave, r.LongPause) +
-									h.succinctModeExplanation(requestEnv.Session.User.UserID, config, l) +
-									l.Get(r.LongPause, r.WhatDoYouWantToDoNext))},
-								SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-							}
-						}
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								OutputSpeech: plainText(l.GetTemplated(r.NewEntryConfirmation, map[string]interface{}{
-									"Date": intent.Slots["date"].Value,
-									"Text": strings.Join(sessionAttributes.Drafts[intent.Slots["date"].Value], ". "),
-								})),
-								Directives: []interface{}{alexa.DialogDirective{Type: "Dialog.ConfirmIntent", UpdatedIntent: &intent}},
-								Reprompt:   &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.NewEntryConfirmationReprompt))},
-							},
-							SessionAttributes: requestEnv.Session.Attributes,
-						}
-					default:
-						sessionAttributes.Drafts[intent.Slots["date"].Value] = append(sessionAttributes.Drafts[intent.Slots["date"].Value], intent.Slots["text"].Value)
-
-						return &alexa.ResponseEnvelope{Version: "1.0",
-							Response: &alexa.Response{
-								OutputSpeech: plainText(l.GetTemplated(r.IRepeat, map[string]interface{}{"Text": intent.Slots["text"].Value})),
-								Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "text"}},
-								Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.NextPartPleaseReprompt))},
-							},
-							SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-						}
-					}
-				case "CONFIRMED":
-					date, _, dateType := DateFrom(intent.Slots["date"].Value)
-					if dateType != DayDate {
-						panic(errors.Errorf("Could not parse string '%v' to day date", intent.Slots["date"].Value))
-					}
-
-					journal.AddEntry(date, strings.Join(sessionAttributes.Drafts[intent.Slots["date"].Value], ". "))
-
-					sessionAttributes.Drafting = false
-					delete(sessionAttributes.Drafts, intent.Slots["date"].Value)
-
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response: &alexa.Response{OutputSpeech: plainText(l.Get(r.OkaySaved, r.LongPause) +
-							h.succinctModeExplanation(requestEnv.Session.User.UserID, config, l) +
-							l.Get(r.LongPause, r.WhatDoYouWantToDoNext))},
-						SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-					}
-
-				case "DENIED":
-					sessionAttributes.Drafting = false
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response: &alexa.Response{OutputSpeech: plainText(l.Get(r.OkayNotSaved, r.LongPause) +
-							h.succinctModeExplanation(requestEnv.Session.User.UserID, config, l) +
-							l.Get(r.LongPause, r.WhatDoYouWantToDoNext))},
-						SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-					}
-				default:
-					panic(errors.New("Invalid intent.ConfirmationStatus"))
-				}
-			default:
-				panic(errors.New("Invalid requestEnv.Request.DialogState"))
-			}
-		case "ListAllEntriesInDate":
-			switch requestEnv.Request.DialogState {
-			case "STARTED", "IN_PROGRESS":
-				return pureDelegate(&intent, requestEnv.Session.Attributes)
-			case "COMPLETED":
-				_, monthDate, dateType := DateFrom(intent.Slots["date"].Value)
-				if dateType == MonthDate {
-					return listAllEntriesInDate(&journal, monthDate, requestEnv.Session.Attributes, h.errorInterpreter, l)
-				}
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{
-						OutputSpeech: plainText(l.Get(r.DidNotUnderstandTryAgain)),
-						Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.DidNotUnderstandTryAgain))},
-					},
-					SessionAttributes: requestEnv.Session.Attributes,
-				}
-			default:
-				panic(errors.New("Invalid requestEnv.Request.DialogState"))
-			}
-		case "ReadAllEntriesInDate", "ReadExistingEntryAbsoluteDateIntent":
-			switch requestEnv.Request.DialogState {
-			case "STARTED", "IN_PROGRESS":
-				return pureDelegate(&intent, requestEnv.Session.Attributes)
-			case "COMPLETED":
-				entryDate, monthDate, dateType := DateFrom(intent.Slots["date"].Value)
-				if dateType == Invalid {
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response: &alexa.Response{
-							OutputSpeech: plainText(fmt.Sprintf(l.Get(r.DidNotUnderstandTryAgain, r.ExampleDateQuery))),
-							Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.Get(r.DidNotUnderstandTryAgain))},
-						},
-						SessionAttributes: requestEnv.Session.Attributes,
-					}
-				}
-				if dateType == MonthDate {
-					return readAllEntriesInDate(&journal, monthDate, requestEnv.Session.Attributes, h.errorInterpreter, l)
-				}
-
-				text, e := journal.GetEntry(entryDate)
-				if e != nil {
-					return plainTextRespEnv(l.Get(r.CouldNotGetEntry, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-						requestEnv.Session.Attributes)
-				}
-				if text != "" {
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response: &alexa.Response{
-							OutputSpeech: plainText(l.GetTemplated(r.ReadEntry, map[string]interface{}{
-								"WeekDay": l.Weekday(entryDate.Weekday()),
-								"Date":    entryDate.String(),
-								"Text":    text,
-							}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-						},
-						SessionAttributes: requestEnv.Session.Attributes,
-					}
-				}
-				closestEntry, e := journal.GetClosestEntry(entryDate)
-				if e != nil {
-					return plainTextRespEnv(l.Get(r.CouldNotGetEntry, r.ShortPause)+h.errorInterpreter.Interpret(e, l), requestEnv.Session.Attributes)
-				}
-				if closestEntry == (j.Entry{}) {
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response:          &alexa.Response{OutputSpeech: plainText(l.Get(r.JournalIsEmpty, r.LongPause, r.WhatDoYouWantToDoNext, r.ShortPause, r.NewEntryExample))},
-						SessionAttributes: requestEnv.Session.Attributes,
-					}
-				}
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{
-						OutputSpeech: plainText(l.GetTemplated(r.EntryForDateNotFound, map[string]interface{}{
-							"SearchDate": entryDate.String(),
-							"WeekDay":    l.Weekday(entryDate.Weekday()),
-							"Date":       closestEntry.EntryDate.String(),
-							"Text":       closestEntry.EntryText,
-						}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-					},
-					SessionAttributes: requestEnv.Session.Attributes,
-				}
-			default:
-				panic(errors.New("Invalid requestEnv.Request.DialogState"))
-			}
-		case "ReadExistingEntryRelativeDateIntent":
-			today := date.NewAt(time.Now())
-			x, e := strconv.Atoi(intent.Slots["number"].Value)
-			if e != nil ||
-				intent.Slots["unit"].Resolutions.ResolutionsPerAuthority[0].Status["code"] == "ER_SUCCESS_NO_MATCH" {
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{
-						OutputSpeech: plainText(l.Get(r.DidNotUnderstandTryAgain, r.ShortPause, r.ExampleRelativeDateQuery)),
-						Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ElicitSlot", SlotToElicit: "unit"}},
-					},
-					SessionAttributes: mapStringInterfaceFrom(sessionAttributes),
-				}
-			}
-			var entryDate date.Date
-			switch intent.Slots["unit"].Resolutions.ResolutionsPerAuthority[0].Values[0].Value.ID {
-			case "DAYS":
-				entryDate = today.AddDate(0, 0, -x)
-			case "MONTHS":
-				entryDate = today.AddDate(0, -x, 0)
-			case "YEARS":
-				entryDate = today.AddDate(-x, 0, 0)
-			default:
-				panic(errors.New("Invalid resolution"))
-			}
-
-			text, e := journal.GetEntry(entryDate)
-			if e != nil {
-				return plainTextRespEnv(l.Get(r.CouldNotGetEntry, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-					requestEnv.Session.Attributes)
-			}
-			if text != "" {
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{
-						OutputSpeech: plainText(l.GetTemplated(r.ReadEntry, map[string]interface{}{
-							"WeekDay": l.Weekday(entryDate.Weekday()),
-							"Date":    entryDate.String(),
-							"Text":    text,
-						}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-					},
-					SessionAttributes: requestEnv.Session.Attributes,
-				}
-			}
-			closestEntry, e := journal.GetClosestEntry(entryDate)
-			if e != nil {
-				return plainTextRespEnv(l.Get(r.CouldNotGetEntry, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-					requestEnv.Session.Attributes)
-			}
-			if closestEntry == (j.Entry{}) {
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{OutputSpeech: plainText(l.Get(
-						r.JournalIsEmpty, r.LongPause, r.WhatDoYouWantToDoNext, r.ShortPause, r.NewEntryExample))},
-					SessionAttributes: requestEnv.Session.Attributes,
-				}
-			}
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response: &alexa.Response{
-					OutputSpeech: plainText(l.GetTemplated(r.EntryForDateNotFound, map[string]interface{}{
-						"SearchDate": entryDate.String(),
-						"WeekDay":    l.Weekday(closestEntry.EntryDate.Weekday()),
-						"Date":       closestEntry.EntryDate.String(),
-						"Text":       closestEntry.EntryText,
-					}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-				},
-				SessionAttributes: requestEnv.Session.Attributes,
-			}
-		case "SearchIntent":
-			entries, e := journal.SearchFor(intent.Slots["query"].Value)
-			if e != nil {
-				return plainTextRespEnv(l.Get(r.SearchError, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-					requestEnv.Session.Attributes)
-			}
-			if len(entries) == 0 {
-				return &alexa.ResponseEnvelope{Version: "1.0",
-					Response: &alexa.Response{
-						OutputSpeech: plainText(l.GetTemplated(r.SearchNoResultsFound,
-							map[string]interface{}{"Query": intent.Slots["query"].Value}) +
-							l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-					},
-					SessionAttributes: requestEnv.Session.Attributes,
-				}
-			}
-			text := l.GetTemplated(r.SearchResults, map[string]interface{}{"Query": intent.Slots["query"].Value})
-			for _, entry := range entries {
-				tuple := l.Weekday(entry.EntryDate.Weekday()) + ", " + entry.EntryDate.String() + ": " + strings.TrimRight(entry.EntryText, ". ") + ". "
-				if len(text)+len(tuple)+len(l.Get(r.WhatDoYouWantToDoNext)) > responseTextLimit {
-					break
-				}
-				text += tuple
-			}
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response:          &alexa.Response{OutputSpeech: plainText(strings.TrimSpace(text) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext))},
-				SessionAttributes: requestEnv.Session.Attributes,
-			}
-		case "DeleteEntryIntent":
-			switch requestEnv.Request.DialogState {
-			case "STARTED":
-				return pureDelegate(&intent, requestEnv.Session.Attributes)
-			case "IN_PROGRESS", "COMPLETED":
-				switch intent.ConfirmationStatus {
-				case "NONE":
-					date, _, dateType := DateFrom(intent.Slots["date"].Value)
-					if dateType != DayDate {
-						intent.Slots["date"] = alexa.IntentSlot{
-							Name:               intent.Slots["date"].Name,
-							ConfirmationStatus: intent.Slots["date"].ConfirmationStatus,
-							Resolutions:        intent.Slots["date"].Resolutions,
-							Value:              "",
-						}
-						return pureDelegate(&intent, requestEnv.Session.Attributes)
-					}
-					util.PanicOnError(errors.Wrapf(e, "Could not convert string '%v' to date", intent.Slots["date"].Value))
-
-					entry, e := journal.GetEntry(date)
-					if e != nil {
-						return plainTextRespEnv(l.Get(r.DeleteEntryCouldNotGetEntry, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-							requestEnv.Session.Attributes)
-					}
-					if entry == "" {
-						return plainTextRespEnv(l.Get(r.DeleteEntryNotFound), requestEnv.Session.Attributes)
-					}
-					return &alexa.ResponseEnvelope{Version: "1.0",
-						Response: &alexa.Response{
-							OutputSpeech: plainText(l.GetTemplated(r.DeleteEntryConfirmation, map[string]interface{}{"Entry": entry})),
-							Directives:   []interface{}{alexa.DialogDirective{Type: "Dialog.ConfirmIntent", UpdatedIntent: &intent}},
-							Reprompt:     &alexa.Reprompt{OutputSpeech: plainText(l.GetTemplated(r.DeleteEntryConfirmation, map[string]interface{}{"Entry": entry}))},
-						},
-						SessionAttributes: requestEnv.Session.Attributes,
-					}
-				case "CONFIRMED":
-					date, e := date.AutoParse(intent.Slots["date"].Value)
-					util.PanicOnError(errors.Wrapf(e, "Could not convert string '%v' to date", intent.Slots["date"].Value))
-
-					e = journal.DeleteEntry(date)
-					if e != nil {
-						return plainTextRespEnv(l.Get(r.DeleteEntryError, r.ShortPause)+h.errorInterpreter.Interpret(e, l),
-							requestEnv.Session.Attributes)
-					}
-
-					return plainTextRespEnv(l.Get(r.OkayDeleted, r.LongPause, r.WhatDoYouWantToDoNext), requestEnv.Session.Attributes)
-				case "DENIED":
-					return plainTextRespEnv(l.Get(r.OkayNotDeleted, r.LongPause, r.WhatDoYouWantToDoNext), requestEnv.Session.Attributes)
-				default:
-					panic(errors.New("Invalid intent.ConfirmationStatus"))
-				}
-			default:
-				panic(errors.New("Invalid requestEnv.Request.DialogState"))
-			}
+	return responseEnv
+}
 
-		case "AMAZON.HelpIntent":
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response:          &alexa.Response{OutputSpeech: plainText(l.Get(r.Help))},
-				SessionAttributes: requestEnv.Session.Attributes,
-			}
-		case "AMAZON.CancelIntent", "AMAZON.StopIntent":
-			return &alexa.ResponseEnvelope{Version: "1.0",
-				Response:          &alexa.Response{ShouldSessionEnd: true},
-				SessionAttributes: requestEnv.Session.Attributes,
+func (h *JournalSkill) handle(in *Input) *alexa.ResponseEnvelope {
+	for _, interceptors := range [][]RequestInterceptor{h.requestInterceptors, h.coreInterceptors} {
+		for _, interceptor := range interceptors {
+			if responseEnv := interceptor.Intercept(in); responseEnv != nil {
+				return responseEnv
 			}
-		default:
-			panic(errors.New("Invalid Intent"))
 		}
+	}
+	for _, handler := range h.handlers {
+		if handler.CanHandle(in) {
+			return handler.Handle(in)
+		}
+	}
+	if in.RequestEnv.Request.Type == "IntentRequest" {
+		panic(errors.New("Invalid Intent"))
+	}
+	panic(errors.New("Invalid Request"))
+}
 
-	case "SessionEndedRequest":
-		return &alexa.ResponseEnvelope{Version: "1.0"}
+// spokenEntryTextOn returns the spoken text of all entries on entryDate, like Journal.GetEntry does for their plain text.
+func spokenEntryTextOn(ctx context.Context, journal *j.Journal, entryDate date.Date, l *locale.Localizer) (string, error) {
+	entries, e := journal.GetEntriesOn(ctx, entryDate)
+	if e != nil {
+		return "", errors.Wrap(e, "Could not get entry")
+	}
+	var texts []string
+	for _, entry := range entries {
+		texts = append(texts, spokenTextOf(entry, l))
+	}
+	return strings.Join(texts, ". "), nil
+}
 
-	default:
-		panic(errors.New("Invalid Request"))
+// spokenTextOf returns the entry's text as it should be read to the user. Items of list entries are enumerated.
+func spokenTextOf(entry j.Entry, l *locale.Localizer) string {
+	if len(entry.Items) == 0 {
+		return entry.EntryText
 	}
+	return spokenListOf(entry.Items, l)
 }
 
-func (h *JournalSkill) succinctModeExplanation(userID string, config Config, l *locale.Localizer) string {
-	if config.ShouldExplainAboutSuccinctMode {
-		config.ShouldExplainAboutSuccinctMode = false
-		h.configService.PersistConfig(userID, config)
-		return l.Get(r.SuccinctModeExplanation)
+func spokenListOf(items []string, l *locale.Localizer) string {
+	var texts []string
+	for i, item := range items {
+		texts = append(texts, l.GetTemplated(r.ListItem, map[string]interface{}{"Number": i + 1, "Item": strings.TrimRight(item, ". ")}))
 	}
-	return ""
+	return strings.Join(texts, ". ")
 }
 
-func listAllEntriesInDate(journal *j.Journal, dateSlotValue string, sessionAttributes map[string]interface{}, errorInterpreter ErrorInterpreter, l *locale.Localizer) *alexa.ResponseEnvelope {
-	entries, e := journal.GetEntries(dateSlotValue[:7])
-	if e != nil {
-		return plainTextRespEnv(l.Get(r.CouldNotGetEntries, r.ShortPause)+errorInterpreter.Interpret(e, l),
-			sessionAttributes)
+// randomEntryNotIn picks a random entry, preferring entries whose IDs are not in recentIDs.
+// If all entries were served recently, the least recently served ones are preferred.
+func randomEntryNotIn(recentIDs []string, entries []j.Entry) j.Entry {
+	recency := make(map[string]int)
+	for i, id := range recentIDs {
+		recency[id] = i + 1
 	}
-	if len(entries) == 0 {
-		return &alexa.ResponseEnvelope{Version: "1.0",
-			Response: &alexa.Response{
-				OutputSpeech: plainText(l.GetTemplated(r.NoEntriesInTimeRangeFound, map[string]interface{}{
-					"TimeRange": readableStringFrom(dateSlotValue, l)}) +
-					l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-			},
-			SessionAttributes: sessionAttributes,
+	var candidates []j.Entry
+	minRecency := len(recentIDs) + 1
+	for _, entry := range entries {
+		switch {
+		case recency[entry.ID] < minRecency:
+			minRecency = recency[entry.ID]
+			candidates = []j.Entry{entry}
+		case recency[entry.ID] == minRecency:
+			candidates = append(candidates, entry)
 		}
 	}
-	var tuples []string
-	for _, entry := range entries {
-		tuples = append(tuples, l.Weekday(entry.EntryDate.Weekday())+", "+entry.EntryDate.String()+": "+entry.EntryText)
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// recentMemoryIDsWith returns recentIDs with id appended, keeping only the maxRecentMemories most recent IDs.
+func recentMemoryIDsWith(recentIDs []string, id string) []string {
+	result := append(append([]string{}, recentIDs...), id)
+	if len(result) > maxRecentMemories {
+		result = result[len(result)-maxRecentMemories:]
 	}
-	// TODO: limit response length
-	return &alexa.ResponseEnvelope{Version: "1.0",
-		Response: &alexa.Response{
-			OutputSpeech: plainText(l.GetTemplated(r.EntriesInTimeRange, map[string]interface{}{
-				"Date": readableStringFrom(dateSlotValue, l), "Entries": strings.Join(tuples, ". "),
-			}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-		},
-		SessionAttributes: sessionAttributes,
+	return result
+}
+
+// speakWhileSlow makes Alexa say that the journal is being opened, if the request is still being processed after
+// progressiveResponseThreshold, e.g. because a long journal is being loaded or searched. The returned function must
+// be called as soon as the response is ready.
+func (h *JournalSkill) speakWhileSlow(in *Input) (stop func()) {
+	if in.RequestContext.apiEndpoint() == "" {
+		return func() {}
 	}
+	timer := time.AfterFunc(h.progressiveResponseThreshold, func() {
+		e := h.progressiveResponder.Speak(in.Ctx, in.RequestContext.apiEndpoint(), in.RequestContext.apiAccessToken(),
+			in.RequestEnv.Request.RequestID, in.Localizer.Get(r.OpeningJournal))
+		if e != nil {
+			in.Log.Infow("Could not send progressive response", "error", e)
+		}
+	})
+	return func() { timer.Stop() }
 }
 
-func readAllEntriesInDate(journal *j.Journal, dateSlotValue string, sessionAttributes map[string]interface{}, errorInterpreter ErrorInterpreter, l *locale.Localizer) *alexa.ResponseEnvelope {
-	entries, e := journal.GetEntries(dateSlotValue[:7])
+// onThisDayGreeting returns the entries from this day in previous years as text. It returns false
+// if there are no such entries or the journal couldn't be read, in which case the regular greeting should be used.
+func (h *JournalSkill) onThisDayGreeting(ctx context.Context, accessToken string, location JournalLocation, l *locale.Localizer, log *zap.SugaredLogger) (string, bool) {
+	journal, e := h.journalProvider.Get(ctx, accessToken, location)
 	if e != nil {
-		return plainTextRespEnv(l.Get(r.CouldNotGetEntries, r.ShortPause)+errorInterpreter.Interpret(e, l),
-			sessionAttributes)
+		log.Errorw("Error while getting journal via journalProvider for on-this-day greeting", "error", e)
+		return "", false
+	}
+	today := date.Today()
+	entries, e := journal.GetEntriesOnDayOfYear(ctx, today.Month(), today.Day(), today.Year())
+	if e != nil {
+		log.Errorw("Error while getting entries for on-this-day greeting", "error", e)
+		return "", false
 	}
 	if len(entries) == 0 {
-		return &alexa.ResponseEnvelope{Version: "1.0",
-			Response: &alexa.Response{
-				OutputSpeech: plainText(l.GetTemplated(r.NoEntriesInTimeRangeFound, map[string]interface{}{
-					"TimeRange": readableStringFrom(dateSlotValue, l)}) +
-					l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-			},
-			SessionAttributes: sessionAttributes,
+		return "", false
+	}
+	return onThisDayText(entries, l), true
+}
+
+func onThisDayText(entries []j.Entry, l *locale.Localizer) string {
+	text := l.Get(r.OnThisDayIntro)
+	var texts []string
+	for i, entry := range entries {
+		texts = append(texts, strings.TrimRight(spokenTextOf(entry, l), ". "))
+		if i+1 < len(entries) && entries[i+1].EntryDate.Year() == entry.EntryDate.Year() {
+			continue
+		}
+		yearText := " " + l.GetTemplated(r.OnThisDayYear, map[string]interface{}{
+			"Year": entry.EntryDate.Year(),
+			"Text": strings.Join(texts, ". "),
+		})
+		if len(text)+len(yearText)+len(l.Get(r.WhatDoYouWantToDoNext)) > responseTextLimit {
+			break
 		}
+		text += yearText
+		texts = nil
 	}
-	var tuples []string
-	for _, entry := range entries {
-		tuples = append(tuples, l.Weekday(entry.EntryDate.Weekday())+", "+entry.EntryDate.String()+": "+entry.EntryText)
+	return text
+}
+
+// errorResponse tells the user about e after text. If the account needs to be linked again, it ends the session
+// and sends a LinkAccount card.
+func (h *JournalSkill) errorResponse(in *Input, text string, e error) *alexa.ResponseEnvelope {
+	response := in.Response().Speak(text + h.errorInterpreter.Interpret(in.Ctx, e, in.Localizer))
+	if h.errorInterpreter.RequiresAccountLinking(e) {
+		response.LinkAccountCard().EndSession()
 	}
-	return &alexa.ResponseEnvelope{Version: "1.0",
-		Response: &alexa.Response{
-			OutputSpeech: plainText(l.GetTemplated(r.EntriesInTimeRange, map[string]interface{}{
-				"Date": readableStringFrom(dateSlotValue, l), "Entries": strings.Join(tuples, ". "),
-			}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)),
-		},
-		SessionAttributes: sessionAttributes,
+	return response.Build()
+}
+
+// parseWarning tells the user if some rows were skipped while reading the journal. It returns an empty
+// string otherwise.
+func (h *JournalSkill) parseWarning(in *Input) string {
+	e := in.Journal.ParseReport().Err()
+	if e == nil {
+		return ""
+	}
+	return h.errorInterpreter.Interpret(in.Ctx, e, in.Localizer) + in.Localizer.Get(r.ShortPause)
+}
+
+// resolvedValueID returns the ID of the custom slot value the slot resolved to, or "" if it didn't resolve.
+func resolvedValueID(slot alexa.IntentSlot) string {
+	for _, resolution := range slot.Resolutions.ResolutionsPerAuthority {
+		if resolution.Status["code"] == "ER_SUCCESS_MATCH" && len(resolution.Values) > 0 {
+			return resolution.Values[0].Value.ID
+		}
 	}
+	return ""
 }
 
 func readableStringFrom(dateLike string, l Localizer) string {
-	r := regexp.MustCompile(`(\d{4})-(\d{2})(-XX)?`)
+	r := regexp.MustCompile(`^(\d{4})-(\d{2})(-XX)?$`)
 	if matched := r.MatchString(dateLike); matched {
 		subMatches := r.FindStringSubmatch(dateLike)
 		yearString := subMatches[1]
@@ -681,32 +504,11 @@ func plainText(text string) *alexa.OutputSpeech {
 	return &alexa.OutputSpeech{Type: "PlainText", Text: text}
 }
 
-func plainTextRespEnv(text string, attributes map[string]interface{}) *alexa.ResponseEnvelope {
-	return &alexa.ResponseEnvelope{Version: "1.0",
-		Response:          &alexa.Response{OutputSpeech: plainText(text)},
-		SessionAttributes: attributes,
-	}
-}
-
-func pureDelegate(intent *alexa.Intent, sessionAttributes map[string]interface{}) *alexa.ResponseEnvelope {
-	return &alexa.ResponseEnvelope{Version: "1.0",
-		Response: &alexa.Response{
-			Directives: []interface{}{
-				alexa.DialogDirective{
-					Type:          "Dialog.Delegate",
-					UpdatedIntent: intent,
-				},
-			},
-		},
-		SessionAttributes: sessionAttributes,
-	}
-}
-
 func internalError(l *i18n.Localizer) *alexa.ResponseEnvelope {
-	return &alexa.ResponseEnvelope{Version: "1.0", Response: &alexa.Response{
-		OutputSpeech:     plainText(l.MustLocalize(&i18n.LocalizeConfig{MessageID: r.InternalError.String()})),
-		ShouldSessionEnd: true,
-	}}
+	return NewResponse().
+		Speak(l.MustLocalize(&i18n.LocalizeConfig{MessageID: r.InternalError.String()})).
+		EndSession().
+		Build()
 }
 
 func mapStringInterfaceFrom(sessionAttributes SessionAttributes) map[string]interface{} {
@@ -1,19 +1,31 @@
 package journalskill_test
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"runtime/debug"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/petergtz/alexa-journal"
 	"github.com/petergtz/alexa-journal/cmd/skill/factory"
 	"github.com/petergtz/alexa-journal/drive"
+	"github.com/petergtz/alexa-journal/journal"
 	. "github.com/petergtz/alexa-journal/matchers"
+	"github.com/petergtz/alexa-journal/progressive"
+	"github.com/petergtz/alexa-journal/reminders"
+	"github.com/petergtz/alexa-journal/tsv"
 	"github.com/petergtz/go-alexa"
 	"github.com/petergtz/pegomock"
 	. "github.com/petergtz/pegomock/ginkgo_compatible"
+	"github.com/pkg/errors"
+	"github.com/rickb777/date"
 	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
 )
 
 //go:generate pegomock generate --use-experimental-model-gen --package journalskill_test JournalProvider
@@ -33,9 +45,11 @@ var _ = Describe("Skill processes request", func() {
 
 		journalProvider = NewMockJournalProvider()
 		errorReporter = NewMockErrorReporter()
-		Whenever(func() { errorReporter.ReportPanic(AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()) }).
+		Whenever(func() {
+			errorReporter.ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope())
+		}).
 			Then(func(params []pegomock.Param) pegomock.ReturnValues {
-				e := params[0]
+				e := params[1]
 				logger.Sugar().Error(fmt.Sprintf("%v\n%s", e, debug.Stack()))
 				return nil
 			})
@@ -44,7 +58,10 @@ var _ = Describe("Skill processes request", func() {
 			logger.Sugar(),
 			errorReporter,
 			factory.CreateI18nBundle(),
-			&factory.EmptyConfigService{})
+			factory.NewMemoryConfigService(),
+			nil,
+			nil,
+			nil)
 	})
 
 	Context("Session missing from request envelope", func() {
@@ -52,7 +69,9 @@ var _ = Describe("Skill processes request", func() {
 			It("reports a panic to the error reporter before telling the user there was an internal error in English (default locale)", func() {
 				var stackTrace string
 
-				Whenever(func() { errorReporter.ReportPanic(AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()) }).
+				Whenever(func() {
+					errorReporter.ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope())
+				}).
 					Then(func([]pegomock.Param) pegomock.ReturnValues {
 						stackTrace = string(debug.Stack())
 						return nil
@@ -60,7 +79,7 @@ var _ = Describe("Skill processes request", func() {
 
 				response := skill.ProcessRequest(&alexa.RequestEnvelope{Request: &alexa.Request{}})
 
-				reportedPanic, _ := errorReporter.VerifyWasCalledOnce().ReportPanic(AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).GetCapturedArguments()
+				_, reportedPanic, _ := errorReporter.VerifyWasCalledOnce().ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).GetCapturedArguments()
 				Expect(stackTrace).To(ContainSubstring("alexa-journal/skill.go"))
 				Expect(reportedPanic).NotTo(BeEmpty())
 
@@ -74,7 +93,7 @@ var _ = Describe("Skill processes request", func() {
 					Request: &alexa.Request{Locale: "de_DE"},
 				})
 
-				reportedPanic, _ := errorReporter.VerifyWasCalledOnce().ReportPanic(AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).GetCapturedArguments()
+				_, reportedPanic, _ := errorReporter.VerifyWasCalledOnce().ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).GetCapturedArguments()
 				Expect(reportedPanic).To(BeEquivalentTo("invalid memory address or nil pointer dereference"))
 				Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("interner Fehler"))
 			})
@@ -111,4 +130,809 @@ var _ = Describe("Skill processes request", func() {
 			})
 		})
 	})
+
+	Context("Google account link expired", func() {
+		It("tells user to link accounts again and sends a LinkAccount card", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, errors.Wrap(&googleapi.Error{Code: 401, Message: "Invalid Credentials"}, "Could not get values"))
+
+			respEnv := skill.ProcessRequest(&alexa.RequestEnvelope{
+				Request: &alexa.Request{Locale: "en_US", Type: "IntentRequest", Intent: alexa.Intent{Name: "ReadExistingEntryAbsoluteDateIntent"}},
+				Session: &alexa.Session{
+					User: struct {
+						UserID      string "json:\"userId\""
+						AccessToken string "json:\"accessToken\""
+					}{AccessToken: "expired-token"},
+				},
+			})
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Please link Alexa with your Google account again"))
+			Expect(respEnv.Response.Card.Type).To(Equal("LinkAccount"))
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeTrue())
+		})
+	})
+
+	Context("Intent requests", func() {
+		intentRequest := func(intentName string) *alexa.RequestEnvelope {
+			return &alexa.RequestEnvelope{
+				Request: &alexa.Request{Locale: "en_US", Type: "IntentRequest", Intent: alexa.Intent{Name: intentName}},
+				Session: &alexa.Session{
+					User: struct {
+						UserID      string "json:\"userId\""
+						AccessToken string "json:\"accessToken\""
+					}{AccessToken: "some-token"},
+					Attributes: map[string]interface{}{"drafting": true},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, nil)
+		})
+
+		It("hands the request to the handler registered for the intent", func() {
+			respEnv := skill.ProcessRequest(intentRequest("AMAZON.StopIntent"))
+
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeTrue())
+			Expect(respEnv.SessionAttributes).To(HaveKeyWithValue("drafting", true))
+		})
+
+		It("reports a panic when no handler is registered for the intent", func() {
+			respEnv := skill.ProcessRequest(intentRequest("UnknownIntent"))
+
+			_, reportedPanic, _ := errorReporter.VerifyWasCalledOnce().ReportPanic(AnyContextContext(), AnyInterface(), AnyPtrToGoAlexaRequestEnvelope()).GetCapturedArguments()
+			Expect(reportedPanic).To(MatchError("Invalid Intent"))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("internal error"))
+		})
+	})
+
+	Context("Named journals", func() {
+		journalRequest := func(intentName string, journalName string) *alexa.RequestEnvelope {
+			return &alexa.RequestEnvelope{
+				Request: &alexa.Request{Locale: "en_US", Type: "IntentRequest", Intent: alexa.Intent{Name: intentName,
+					Slots: map[string]alexa.IntentSlot{"journalName": {Name: "journalName", Value: journalName}}}},
+				Session: &alexa.Session{
+					User: struct {
+						UserID      string "json:\"userId\""
+						AccessToken string "json:\"accessToken\""
+					}{UserID: "some-user", AccessToken: "some-token"},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, nil)
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				nil)
+		})
+
+		It("creates a journal in its own spreadsheet and keeps using it", func() {
+			respEnv := skill.ProcessRequest(journalRequest("CreateJournalIntent", "Dream"))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("I've created your dream journal and opened it."))
+
+			skill.ProcessRequest(journalRequest("AMAZON.HelpIntent", ""))
+
+			journalProvider.VerifyWasCalled(pegomock.Twice()).Get(AnyContextContext(), pegomock.EqString("some-token"),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal - dream", AlternativeNames: []string{"Tagebuch - dream"}}))
+		})
+
+		It("doesn't remember a journal whose spreadsheet couldn't be created", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal - travel", AlternativeNames: []string{"Tagebuch - travel"}}))).
+				ThenReturn(journal.Journal{}, errors.New("some error"))
+
+			respEnv := skill.ProcessRequest(journalRequest("CreateJournalIntent", "travel"))
+			Expect(respEnv.Response.OutputSpeech.Text).NotTo(ContainSubstring("I've created your travel journal"))
+
+			respEnv = skill.ProcessRequest(journalRequest("ListJournalsIntent", ""))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("You only have your main journal."))
+		})
+
+		It("lists the journals", func() {
+			skill.ProcessRequest(journalRequest("CreateJournalIntent", "work"))
+			skill.ProcessRequest(journalRequest("CreateJournalIntent", "dream"))
+
+			respEnv := skill.ProcessRequest(journalRequest("ListJournalsIntent", ""))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Besides your main journal, you have these journals: work, dream. Your dream journal is open."))
+		})
+
+		It("switches between journals", func() {
+			skill.ProcessRequest(journalRequest("CreateJournalIntent", "work"))
+
+			switchToMain := journalRequest("SwitchJournalIntent", "default")
+			switchToMain.Request.Intent.Slots["journalName"] = alexa.IntentSlot{Name: "journalName", Value: "default",
+				Resolutions: alexa.ResolutionsPerAuthority{ResolutionsPerAuthority: []alexa.Resolution{{
+					Status: map[string]string{"code": "ER_SUCCESS_MATCH"},
+					Values: []alexa.Value{{Value: alexa.NameID{Name: "main", ID: "MAIN"}}},
+				}}}}
+			respEnv := skill.ProcessRequest(switchToMain)
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, your main journal is open."))
+
+			respEnv = skill.ProcessRequest(journalRequest("SwitchJournalIntent", "work"))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, your work journal is open."))
+		})
+
+		It("doesn't switch to journals that don't exist", func() {
+			respEnv := skill.ProcessRequest(journalRequest("SwitchJournalIntent", "travel"))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("You don't have a travel journal yet."))
+
+			skill.ProcessRequest(journalRequest("AMAZON.HelpIntent", ""))
+
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", AlternativeNames: []string{"Tagebuch"}}))
+		})
+	})
+
+	Context("Several spreadsheets with the journal's name", func() {
+		var request func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope
+
+		BeforeEach(func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewMultipleFilesFoundError("Journal", []JournalFile{
+					{ID: "first-id", ModifiedTime: time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC)},
+					{ID: "second-id", ModifiedTime: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "second-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{}, nil)
+			request = func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope {
+				return &alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", Intent: intent},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+						Attributes: attributes,
+					},
+				}
+			}
+		})
+
+		It("lets the user choose one and uses it from then on", func() {
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(Equal("I found 2 spreadsheets called Journal in your Google Drive. " +
+				"Number 1, last changed on october 3, 2025. Number 2, last changed on january 20, 2026. " +
+				"Which one should I use for your journal? Say e.g. \"number 1\"."))
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeFalse())
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "ChooseJournalFileIntent",
+				Slots: map[string]alexa.IntentSlot{"number": {Name: "number", Value: "2"}}}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, I'll use number 2 from now on."))
+			Expect(respEnv.SessionAttributes).NotTo(HaveKey("journalFileCandidates"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+		})
+
+		It("takes a number said on its own as the answer", func() {
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "RateMoodIntent",
+				Slots: map[string]alexa.IntentSlot{"rating": {Name: "rating", Value: "2"}}}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, I'll use number 2 from now on."))
+		})
+	})
+
+	Context("Journal under its names in several languages", func() {
+		var request func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope
+
+		BeforeEach(func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				nil)
+			request = func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope {
+				return &alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", Intent: intent},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+						Attributes: attributes,
+					},
+				}
+			}
+		})
+
+		It("keeps using the spreadsheet found first, independent of the language", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "tagebuch-id"}, nil)
+
+			skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", AlternativeNames: []string{"Tagebuch"}}))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))
+		})
+
+		It("forgets a remembered spreadsheet that doesn't exist anymore and looks up the journal by name again", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "tagebuch-id"}, nil)
+			skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{}, errors.Wrap(&googleapi.Error{Code: 404, Message: "File not found"}, "Could not get sheets properties"))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "journal-id"}, nil)
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))
+		})
+
+		It("offers to merge the spreadsheets and merges them", func() {
+			target := &tsv.StringBasedTabularData{}
+			source := &tsv.StringBasedTabularData{}
+			source.AppendRows(context.Background(), [][]string{
+				journal.Header,
+				{"2025-10-03 20:00:00", "2025-10-03", "Ein schöner Tag", "some-id", "", "", ""},
+			})
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "journal-id", Name: "Journal"},
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: target, StorageID: "journal-id"}, nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: source, StorageID: "tagebuch-id"}, nil)
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(Equal("I found entries of your journal in several spreadsheets: " +
+				"Journal and Tagebuch. That happens when you use me in different languages. " +
+				"Should I merge all entries into Journal, so that you only have one journal?"))
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeFalse())
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.YesIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Done. All entries are in Journal now."))
+			Expect(respEnv.SessionAttributes).NotTo(HaveKey("journalFilesToMerge"))
+			entries, e := (&journal.Journal{Data: target}).GetEntries(context.Background(), "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].EntryText).To(Equal("Ein schöner Tag"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+		})
+
+		It("keeps the other spreadsheets untouched when the user doesn't want to merge", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "journal-id", Name: "Journal"},
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "journal-id"}, nil)
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.NoIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, from now on I'll only use Journal."))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+		})
+
+		It("uses the spreadsheet it offered to merge into when the user doesn't want to merge", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+					{ID: "journal-id", Name: "Journal"},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "tagebuch-id"}, nil)
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Should I merge all entries into Tagebuch"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.NoIntent"}, respEnv.SessionAttributes))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, from now on I'll only use Tagebuch."))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, respEnv.SessionAttributes))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+		})
+
+		It("looks up the journal by name again when the spreadsheet the user chose not to merge into is gone", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "journal-id", Name: "Journal"},
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+				}))
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.NoIntent"}, respEnv.SessionAttributes))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Okay, from now on I'll only use Journal."))
+
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{}, errors.Wrap(&googleapi.Error{Code: 404, Message: "File not found"}, "Could not get sheets properties"))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "tagebuch-id"}, nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}, StorageID: "tagebuch-id"}, nil)
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("With this skill"))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(),
+				EqAlexaJournalJournalLocation(JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))
+		})
+
+		It("gives up merging when one of the spreadsheets is gone, instead of asking to try again", func() {
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "journal-id", Name: "Journal"},
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{}, errors.Wrap(&googleapi.Error{Code: 404, Message: "File not found"}, "Could not get sheets properties"))
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.YesIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HavePrefix("Something went wrong while merging your journals."))
+			Expect(respEnv.Response.OutputSpeech.Text).To(HaveSuffix("I'll look for your journal by its name."))
+			Expect(respEnv.SessionAttributes).NotTo(HaveKey("journalFilesToMerge"))
+		})
+
+		It("asks to try again when merging fails, and continues the merge", func() {
+			target := &tsv.StringBasedTabularData{}
+			source := &tsv.StringBasedTabularData{}
+			source.AppendRows(context.Background(), [][]string{
+				journal.Header,
+				{"2025-10-03 20:00:00", "2025-10-03", "Ein schöner Tag", "some-id", "", "", ""},
+			})
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, drive.NewSplitJournalError([]JournalFile{
+					{ID: "journal-id", Name: "Journal"},
+					{ID: "tagebuch-id", Name: "Tagebuch"},
+				}))
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "journal-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{Data: target, StorageID: "journal-id"}, nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "tagebuch-id", AlternativeNames: []string{"Tagebuch"}}))).
+				ThenReturn(journal.Journal{}, drive.NewUnavailableError(errors.New("some error"))).
+				ThenReturn(journal.Journal{Data: source, StorageID: "tagebuch-id"}, nil)
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}, nil))
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.YesIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HavePrefix("Something went wrong while merging your journals."))
+			Expect(respEnv.Response.OutputSpeech.Text).To(HaveSuffix("Should I try again?"))
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeFalse())
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.YesIntent"}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Done. All entries are in Journal now."))
+			entries, e := (&journal.Journal{Data: target}).GetEntries(context.Background(), "")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+	})
+
+	Context("Mood question", func() {
+		var (
+			request   func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope
+			saveEntry func() *alexa.ResponseEnvelope
+		)
+
+		BeforeEach(func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}}, nil)
+			request = func(intent alexa.Intent, attributes map[string]interface{}) *alexa.RequestEnvelope {
+				return &alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", DialogState: "IN_PROGRESS", Intent: intent},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+						Attributes: attributes,
+					},
+				}
+			}
+			saveEntry = func() *alexa.ResponseEnvelope {
+				return skill.ProcessRequest(request(alexa.Intent{
+					Name:               "NewEntryIntent",
+					ConfirmationStatus: "CONFIRMED",
+					Slots:              map[string]alexa.IntentSlot{"date": {Name: "date", Value: "2026-10-19"}},
+				}, map[string]interface{}{"drafts": map[string]interface{}{"2026-10-19": []interface{}{"a good day"}}}))
+			}
+		})
+
+		It("isn't asked after saving an entry by default", func() {
+			respEnv := saveEntry()
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HaveSuffix("What do you want to do next in your journal?"))
+		})
+
+		It("is asked after saving an entry once enabled, and the answer rates the entry", func() {
+			skill.ProcessRequest(request(alexa.Intent{Name: "EnableMoodQuestionIntent"}, nil))
+
+			respEnv := saveEntry()
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HaveSuffix("How was your day, on a scale from one to ten?"))
+
+			respEnv = skill.ProcessRequest(request(alexa.Intent{Name: "RateMoodIntent",
+				Slots: map[string]alexa.IntentSlot{"rating": {Name: "rating", Value: "7"}}}, respEnv.SessionAttributes))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("7"))
+		})
+	})
+
+	Context("Journal folder", func() {
+		It("keeps using the journals it remembers and looks for the others in the folder", func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			configService := factory.NewMemoryConfigService()
+			configService.PersistConfig(context.Background(), "some-user", Config{
+				Journals:       []string{"dream"},
+				SpreadsheetIDs: map[string]string{"MAIN": "main-id"},
+			})
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				configService,
+				nil,
+				nil,
+				nil)
+			Whenever(journalProvider.FolderID(AnyContextContext(), pegomock.AnyString(), pegomock.EqString("Diaries"))).
+				ThenReturn("folder-id", nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{}, nil)
+			request := func(intent alexa.Intent) *alexa.RequestEnvelope {
+				return &alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", Intent: intent},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+					},
+				}
+			}
+
+			respEnv := skill.ProcessRequest(request(alexa.Intent{Name: "UseJournalFolderIntent",
+				Slots: map[string]alexa.IntentSlot{"folderName": {Name: "folderName", Value: "Diaries"}}}))
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("in the folder Diaries"))
+
+			skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal", SpreadsheetID: "main-id", FolderID: "folder-id", AlternativeNames: []string{"Tagebuch"}}))
+
+			skill.ProcessRequest(request(alexa.Intent{Name: "SwitchJournalIntent",
+				Slots: map[string]alexa.IntentSlot{"journalName": {Name: "journalName", Value: "dream"}}}))
+			skill.ProcessRequest(request(alexa.Intent{Name: "AMAZON.HelpIntent"}))
+			journalProvider.VerifyWasCalledOnce().Get(AnyContextContext(), pegomock.AnyString(), EqAlexaJournalJournalLocation(
+				JournalLocation{SpreadsheetName: "Journal - dream", FolderID: "folder-id", AlternativeNames: []string{"Tagebuch - dream"}}))
+		})
+	})
+
+	Context("Export", func() {
+		It("writes the export into the journal folder", func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			configService := factory.NewMemoryConfigService()
+			configService.PersistConfig(context.Background(), "some-user", Config{FolderID: "folder-id", FolderName: "Diaries"})
+			fileWriter := &fakeFileWriter{}
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				configService,
+				nil,
+				fileWriter,
+				nil)
+			data := &tsv.StringBasedTabularData{}
+			(&journal.Journal{Data: data}).AddEntry(context.Background(), date.New(2026, 10, 19), "A good day")
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: data}, nil)
+
+			respEnv := skill.ProcessRequest(&alexa.RequestEnvelope{
+				Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", Intent: alexa.Intent{Name: "ExportJournalIntent"}},
+				Session: &alexa.Session{
+					User: struct {
+						UserID      string "json:\"userId\""
+						AccessToken string "json:\"accessToken\""
+					}{UserID: "some-user", AccessToken: "some-token"},
+				},
+			})
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("Journal.html"))
+			Expect(fileWriter.folderID).To(Equal("folder-id"))
+			Expect(fileWriter.filename).To(Equal("Journal.html"))
+			Expect(fileWriter.content).To(ContainSubstring("A good day"))
+		})
+	})
+
+	Context("Gratitude list", func() {
+		It("collects the items one by one, lets the user correct one, and saves them as a list entry", func() {
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				nil)
+			data := &tsv.StringBasedTabularData{}
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: data}, nil)
+			var attributes map[string]interface{}
+			say := func(item string, confirmationStatus string) *alexa.ResponseEnvelope {
+				respEnv := skill.ProcessRequest(&alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", DialogState: "IN_PROGRESS",
+						Intent: alexa.Intent{Name: "GratitudeListIntent", ConfirmationStatus: confirmationStatus,
+							Slots: map[string]alexa.IntentSlot{
+								"date": {Name: "date", Value: "2026-10-19"},
+								"item": {Name: "item", Value: item},
+							}}},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+						Attributes: attributes,
+					},
+				})
+				attributes = respEnv.SessionAttributes
+				return respEnv
+			}
+
+			Expect(say("", "NONE").Response.OutputSpeech.Text).To(HaveSuffix("What's good thing number 1?"))
+			Expect(say("sunshine", "NONE").Response.OutputSpeech.Text).To(Equal("I repeat: sunshine. What's good thing number 2?"))
+			Expect(say("coffe", "NONE").Response.OutputSpeech.Text).To(Equal("I repeat: coffe. What's good thing number 3?"))
+			Expect(say("correct", "NONE").Response.OutputSpeech.Text).To(Equal("OK. Please tell me number 2 again. What's good thing number 2?"))
+			Expect(say("coffee", "NONE").Response.OutputSpeech.Text).To(Equal("I repeat: coffee. What's good thing number 3?"))
+
+			respEnv := say("friends", "NONE")
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HavePrefix("Alright. Your good things for 2026-10-19: "))
+			Expect(respEnv.Response.OutputSpeech.Text).To(HaveSuffix("Should I save them like this?"))
+			Expect(respEnv.Response.Directives).To(HaveLen(1))
+			Expect(respEnv.Response.Directives[0].(alexa.DialogDirective).Type).To(Equal("Dialog.ConfirmIntent"))
+
+			respEnv = say("friends", "CONFIRMED")
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(HavePrefix("Okay. Saved."))
+			entries, e := (&journal.Journal{Data: data}).GetEntries(context.Background(), "2026-10-19")
+			Expect(e).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Items).To(Equal([]string{"sunshine", "coffee", "friends"}))
+			Expect(entries[0].EntryText).To(Equal("sunshine. coffee. friends"))
+			Expect(respEnv.SessionAttributes["drafts"]).NotTo(HaveKey("list:2026-10-19"))
+		})
+	})
+
+	Context("Daily reminders", func() {
+		var (
+			server         *httptest.Server
+			receivedBodies []string
+			reminderStatus int
+			request        func(intent alexa.Intent) (*alexa.RequestEnvelope, *RequestContext)
+		)
+
+		BeforeEach(func() {
+			receivedBodies = nil
+			reminderStatus = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body, e := ioutil.ReadAll(req.Body)
+				Expect(e).NotTo(HaveOccurred())
+				Expect(req.Header.Get("Authorization")).To(Equal("Bearer some-api-token"))
+				if req.URL.Path == "/v2/devices/some-device-id/settings/System.timeZone" {
+					w.Write([]byte(`"America/New_York"`))
+					return
+				}
+				receivedBodies = append(receivedBodies, req.Method+" "+req.URL.Path+" "+string(body))
+				w.WriteHeader(reminderStatus)
+				w.Write([]byte(`{"alertToken": "some-alert-token"}`))
+			}))
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				reminders.NewClient(server.Client()),
+				nil,
+				nil)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				ThenReturn(journal.Journal{Data: &tsv.StringBasedTabularData{}}, nil)
+			request = func(intent alexa.Intent) (*alexa.RequestEnvelope, *RequestContext) {
+				requestContext := &RequestContext{}
+				requestContext.System.APIEndpoint = server.URL
+				requestContext.System.APIAccessToken = "some-api-token"
+				requestContext.System.Device.DeviceID = "some-device-id"
+				return &alexa.RequestEnvelope{
+					Request: &alexa.Request{Locale: "en-US", Type: "IntentRequest", Intent: intent},
+					Session: &alexa.Session{
+						User: struct {
+							UserID      string "json:\"userId\""
+							AccessToken string "json:\"accessToken\""
+						}{UserID: "some-user", AccessToken: "some-token"},
+					},
+				}, requestContext
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		setReminder := func() *alexa.ResponseEnvelope {
+			return skill.ProcessRequestWithContext(request(alexa.Intent{Name: "SetReminderIntent",
+				Slots: map[string]alexa.IntentSlot{"time": {Name: "time", Value: "21:30"}}}))
+		}
+
+		It("sets a daily reminder in the device's time zone and cancels it", func() {
+			respEnv := setReminder()
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("I'll remind you every day at 21:30 to write in your journal."))
+			Expect(receivedBodies).To(HaveLen(1))
+			Expect(receivedBodies[0]).To(HavePrefix("POST /v1/alerts/reminders "))
+			Expect(receivedBodies[0]).To(ContainSubstring(`"timeZoneId":"America/New_York"`))
+			Expect(receivedBodies[0]).To(ContainSubstring("FREQ=DAILY;BYHOUR=21;BYMINUTE=30;"))
+
+			respEnv = skill.ProcessRequestWithContext(request(alexa.Intent{Name: "CancelReminderIntent"}))
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(ContainSubstring("I won't remind you anymore to write in your journal."))
+			Expect(receivedBodies).To(HaveLen(2))
+			Expect(receivedBodies[1]).To(Equal("DELETE /v1/alerts/reminders/some-alert-token "))
+		})
+
+		It("replaces the previous reminder", func() {
+			setReminder()
+			setReminder()
+
+			Expect(receivedBodies).To(HaveLen(3))
+			Expect(receivedBodies[1]).To(Equal("DELETE /v1/alerts/reminders/some-alert-token "))
+			Expect(receivedBodies[2]).To(HavePrefix("POST /v1/alerts/reminders "))
+		})
+
+		It("asks for permission when the user hasn't granted it", func() {
+			reminderStatus = http.StatusUnauthorized
+
+			respEnv := setReminder()
+
+			Expect(respEnv.Response.OutputSpeech.Text).To(Equal("To remind you, I need permission for reminders first. Please grant it in the Alexa app."))
+			Expect(respEnv.Response.ShouldSessionEnd).To(BeTrue())
+		})
+	})
+
+	Context("Journal takes long to load", func() {
+		It("tells the user to wait via a progressive response", func() {
+			var receivedBodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body, e := ioutil.ReadAll(req.Body)
+				Expect(e).NotTo(HaveOccurred())
+				receivedBodies = append(receivedBodies, req.URL.Path+" "+string(body))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+			logger, e := zap.NewDevelopment()
+			Expect(e).NotTo(HaveOccurred())
+			skill = NewJournalSkill(journalProvider,
+				&drive.DriveSheetErrorInterpreter{ErrorReporter: errorReporter},
+				logger.Sugar(),
+				errorReporter,
+				factory.CreateI18nBundle(),
+				factory.NewMemoryConfigService(),
+				nil,
+				nil,
+				progressive.NewClient(server.Client())).
+				UseProgressiveResponseThreshold(5 * time.Millisecond)
+			Whenever(journalProvider.Get(AnyContextContext(), pegomock.AnyString(), AnyAlexaJournalJournalLocation())).
+				Then(func([]pegomock.Param) pegomock.ReturnValues {
+					time.Sleep(100 * time.Millisecond)
+					return []pegomock.ReturnValue{journal.Journal{}, errors.New("some error")}
+				})
+			requestContext := &RequestContext{}
+			requestContext.System.APIEndpoint = server.URL
+			requestContext.System.APIAccessToken = "some-api-token"
+
+			skill.ProcessRequestWithContext(&alexa.RequestEnvelope{
+				Request: &alexa.Request{Locale: "en_US", Type: "IntentRequest", RequestID: "some-request-id",
+					Intent: alexa.Intent{Name: "ReadExistingEntryAbsoluteDateIntent"}},
+				Session: &alexa.Session{
+					User: struct {
+						UserID      string "json:\"userId\""
+						AccessToken string "json:\"accessToken\""
+					}{AccessToken: "some-token"},
+				},
+			}, requestContext)
+
+			Expect(receivedBodies).To(ConsistOf(ContainSubstring(`/v1/directives {"header":{"requestId":"some-request-id"}`)))
+			Expect(receivedBodies[0]).To(ContainSubstring("One moment, I'm opening your journal."))
+		})
+	})
 })
+
+type fakeFileWriter struct {
+	folderID, filename, content string
+}
+
+func (w *fakeFileWriter) WriteFile(ctx context.Context, accessToken string, folderID string, filename string, content string) error {
+	w.folderID, w.filename, w.content = folderID, filename, content
+	return nil
+}
@@ -0,0 +1,134 @@
+package journalskill
+
+import (
+	"strconv"
+	"strings"
+
+	j "github.com/petergtz/alexa-journal/journal"
+	"github.com/petergtz/alexa-journal/locale"
+	r "github.com/petergtz/alexa-journal/locale/resources"
+	alexa "github.com/petergtz/go-alexa"
+	"github.com/rickb777/date"
+)
+
+func (h *JournalSkill) averageMood(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	timeRange, ok := timeRangeOrThisYear(in.RequestEnv.Request.Intent.Slots["date"].Value)
+	if !ok {
+		return in.Response().Speak(l.Get(r.DidNotUnderstandTryAgain)).Build()
+	}
+	summary, e := in.Journal.AverageMood(in.Ctx, timeRange)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetMoods, r.ShortPause), e)
+	}
+	if summary.Count == 0 {
+		return in.Response().
+			Speak(l.GetTemplated(r.NoMoodsInTimeRange, map[string]interface{}{"TimeRange": readableStringFrom(timeRange, l)}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	return in.Response().
+		Speak(l.GetTemplated(r.AverageMood, map[string]interface{}{
+			"TimeRange": readableStringFrom(timeRange, l),
+			"Average":   l.Decimal(summary.Average),
+			"Count":     summary.Count,
+		}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) happiestDay(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	timeRange, ok := timeRangeOrThisYear(in.RequestEnv.Request.Intent.Slots["date"].Value)
+	if !ok {
+		return in.Response().Speak(l.Get(r.DidNotUnderstandTryAgain)).Build()
+	}
+	entries, e := in.Journal.HappiestEntries(in.Ctx, timeRange)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetMoods, r.ShortPause), e)
+	}
+	if len(entries) == 0 {
+		return in.Response().
+			Speak(l.GetTemplated(r.NoMoodsInTimeRange, map[string]interface{}{"TimeRange": readableStringFrom(timeRange, l)}) +
+				l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+			Build()
+	}
+	var dates []string
+	for _, entry := range entries {
+		dates = append(dates, l.Weekday(entry.EntryDate.Weekday())+", "+entry.EntryDate.String())
+	}
+	return in.Response().
+		Speak(l.GetTemplated(r.HappiestDays, map[string]interface{}{
+			"TimeRange": readableStringFrom(timeRange, l),
+			"Dates":     strings.Join(dates, "; "),
+			"Mood":      entries[0].Mood,
+		}) + l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func (h *JournalSkill) statistics(in *Input) *alexa.ResponseEnvelope {
+	l := in.Localizer
+	intent := in.RequestEnv.Request.Intent
+	timeRange := ""
+	if intent.Slots["date"].Value != "" {
+		var ok bool
+		timeRange, ok = TimeRangeFrom(intent.Slots["date"].Value)
+		if !ok {
+			return in.Response().Speak(l.Get(r.DidNotUnderstandTryAgain)).Build()
+		}
+	}
+	stats, e := in.Journal.Statistics(in.Ctx, timeRange)
+	if e != nil {
+		return h.errorResponse(in, l.Get(r.CouldNotGetStatistics, r.ShortPause), e)
+	}
+	return in.Response().
+		Speak(h.parseWarning(in) +
+			statisticsText(stats, timeRange, resolvedValueID(intent.Slots["statistic"]), l) +
+			l.Get(r.LongPause, r.WhatDoYouWantToDoNext)).
+		Build()
+}
+
+func statisticsText(stats j.Statistics, timeRange string, statistic string, l *locale.Localizer) string {
+	if stats.NumEntries == 0 {
+		if timeRange == "" {
+			return l.Get(r.JournalIsEmpty)
+		}
+		return l.GetTemplated(r.NoEntriesInTimeRangeFound, map[string]interface{}{"TimeRange": readableStringFrom(timeRange, l)})
+	}
+	inTimeRange := l.Get(r.InTotal)
+	if timeRange != "" {
+		inTimeRange = l.GetTemplated(r.InTimeRange, map[string]interface{}{"TimeRange": readableStringFrom(timeRange, l)})
+	}
+	entryCount := l.GetTemplated(r.StatisticsEntryCount, map[string]interface{}{"InTimeRange": inTimeRange, "Count": stats.NumEntries})
+	daysWritten := l.GetTemplated(r.StatisticsDaysWritten, map[string]interface{}{"InTimeRange": inTimeRange, "Count": stats.NumDays})
+	longestStreak := l.GetTemplated(r.StatisticsLongestStreak, map[string]interface{}{
+		"InTimeRange": inTimeRange,
+		"Length":      stats.LongestStreak.Length,
+		"Start":       stats.LongestStreak.Start.String(),
+		"End":         stats.LongestStreak.End().String(),
+	})
+	firstEntry := l.GetTemplated(r.StatisticsFirstEntry, map[string]interface{}{
+		"InTimeRange": inTimeRange,
+		"WeekDay":     l.Weekday(stats.FirstEntryDate.Weekday()),
+		"Date":        stats.FirstEntryDate.String(),
+	})
+	switch statistic {
+	case "ENTRY_COUNT":
+		return entryCount
+	case "DAYS_WRITTEN":
+		return daysWritten
+	case "LONGEST_STREAK":
+		return longestStreak
+	case "FIRST_ENTRY":
+		return firstEntry
+	default:
+		return strings.Join([]string{entryCount, daysWritten, longestStreak}, l.Get(r.ShortPause))
+	}
+}
+
+// timeRangeOrThisYear is like TimeRangeFrom, but falls back to the current year if no date was given.
+func timeRangeOrThisYear(dateSlotValue string) (string, bool) {
+	if dateSlotValue == "" {
+		return strconv.Itoa(date.Today().Year()), true
+	}
+	return TimeRangeFrom(dateSlotValue)
+}
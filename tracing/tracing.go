@@ -0,0 +1,76 @@
+// Package tracing wraps requests and the backend calls made for them, i.e. Drive, Sheets, DynamoDB and searches,
+// in OpenTelemetry spans. Until a TracerProvider is installed via otel.SetTracerProvider, spans are not recorded
+// and cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/petergtz/alexa-journal"
+
+// Start starts a span as child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends span and marks it as failed if e is not nil.
+func End(span trace.Span, e error) {
+	if e != nil {
+		span.RecordError(e)
+		span.SetStatus(codes.Error, e.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the ID of the trace ctx is part of, or "" if it's not part of a recorded trace.
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// NewTracerProvider returns a TracerProvider exporting spans with the given exporter:
+//
+//   - "stdout" writes them as JSON to w.
+//   - "otlp" sends them via OTLP over HTTP, configured through the standard OTEL_EXPORTER_OTLP_* environment
+//     variables, e.g. OTEL_EXPORTER_OTLP_ENDPOINT.
+//
+// Spans are exported in batches. In Lambda, call ForceFlush after each request, because the function gets frozen
+// between invocations.
+func NewTracerProvider(ctx context.Context, exporter string, serviceName string, w io.Writer) (*sdktrace.TracerProvider, error) {
+	var spanExporter sdktrace.SpanExporter
+	var e error
+	switch exporter {
+	case "stdout":
+		spanExporter, e = stdouttrace.New(stdouttrace.WithWriter(w))
+	case "otlp":
+		spanExporter, e = otlptracehttp.New(ctx)
+	default:
+		return nil, errors.Errorf("Unknown trace exporter %#v. Must be stdout or otlp.", exporter)
+	}
+	if e != nil {
+		return nil, errors.Wrapf(e, "Could not create %v trace exporter", exporter)
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	), nil
+}
@@ -0,0 +1,79 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/petergtz/alexa-journal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		spans                  *tracetest.SpanRecorder
+		previousTracerProvider trace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		spans = tracetest.NewSpanRecorder()
+		previousTracerProvider = otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previousTracerProvider)
+	})
+
+	It("records failed spans as errors", func() {
+		_, span := Start(context.Background(), "sheets.get_values")
+
+		End(span, errors.New("some error"))
+
+		Expect(spans.Ended()).To(HaveLen(1))
+		Expect(spans.Ended()[0].Name()).To(Equal("sheets.get_values"))
+		Expect(spans.Ended()[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans.Ended()[0].Events()).To(HaveLen(1))
+	})
+
+	It("nests spans started with a span's context", func() {
+		ctx, parent := Start(context.Background(), "ProcessRequest")
+		_, child := Start(ctx, "search")
+		End(child, nil)
+		End(parent, nil)
+
+		Expect(spans.Ended()[0].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(TraceID(ctx)).To(Equal(parent.SpanContext().TraceID().String()))
+	})
+
+	It("has no trace ID without a span", func() {
+		Expect(TraceID(context.Background())).To(BeEmpty())
+	})
+
+	Describe("NewTracerProvider", func() {
+		It("exports spans to the writer with the stdout exporter", func() {
+			var out bytes.Buffer
+			tracerProvider, e := NewTracerProvider(context.Background(), "stdout", "alexa-journal", &out)
+			Expect(e).NotTo(HaveOccurred())
+
+			_, span := tracerProvider.Tracer("test").Start(context.Background(), "search")
+			span.End()
+			Expect(tracerProvider.ForceFlush(context.Background())).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring(`"Name":"search"`))
+			Expect(out.String()).To(ContainSubstring("alexa-journal"))
+		})
+
+		It("rejects unknown exporters", func() {
+			_, e := NewTracerProvider(context.Background(), "jaeger", "alexa-journal", nil)
+
+			Expect(e).To(MatchError(ContainSubstring("Unknown trace exporter")))
+		})
+	})
+})
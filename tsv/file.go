@@ -0,0 +1,30 @@
+package tsv
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// LocalFile is a TextFileLoader for a file on the local file system, e.g. a journal downloaded as TSV.
+type LocalFile struct {
+	Path string
+	// ReadOnly makes Upload a no-op, so that commands that only read a journal can't change the file by accident.
+	ReadOnly bool
+}
+
+func (f *LocalFile) Download(ctx context.Context) (string, error) {
+	content, e := ioutil.ReadFile(f.Path)
+	if e != nil {
+		return "", errors.Wrapf(e, "Could not read %v", f.Path)
+	}
+	return string(content), nil
+}
+
+func (f *LocalFile) Upload(ctx context.Context, content string) error {
+	if f.ReadOnly {
+		return nil
+	}
+	return errors.Wrapf(ioutil.WriteFile(f.Path, []byte(content), 0644), "Could not write %v", f.Path)
+}
@@ -1,6 +1,7 @@
 package tsv
 
 import (
+	"context"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -10,11 +11,18 @@ type StringBasedTabularData struct {
 	content string
 }
 
-func (td *StringBasedTabularData) AppendRow(row []string) error {
-	td.content += strings.Join(row, "\t") + "\n"
+func (td *StringBasedTabularData) AppendRow(ctx context.Context, row []string) error {
+	td.content += lineFrom(row) + "\n"
 	return nil
 }
-func (td *StringBasedTabularData) Rows() ([][]string, error) {
+func (td *StringBasedTabularData) AppendRows(ctx context.Context, rows [][]string) error {
+	for _, row := range rows {
+		td.AppendRow(ctx, row)
+	}
+	return nil
+}
+
+func (td *StringBasedTabularData) Rows(ctx context.Context) ([][]string, error) {
 	var rows [][]string
 	for _, line := range strings.Split((td.content), "\n") {
 		rows = append(rows, strings.Split(line, "\t"))
@@ -22,20 +30,44 @@ func (td *StringBasedTabularData) Rows() ([][]string, error) {
 	return rows, nil
 }
 
-func (td *StringBasedTabularData) DeleteRow(i int) error {
+func (td *StringBasedTabularData) DeleteRow(ctx context.Context, i int) error {
 	rows := strings.Split((td.content), "\n")
 	rows = append(rows[:i], rows[i+1:]...)
 	td.content = strings.Join(rows, "\n")
 	return nil
 }
 
-func (td *StringBasedTabularData) Empty() (bool, error) {
+func (td *StringBasedTabularData) UpdateRows(ctx context.Context, updates map[int][]string) error {
+	rows := strings.Split((td.content), "\n")
+	for i, row := range updates {
+		if i < 0 || i >= len(rows) {
+			return errors.Errorf("Row %v does not exist", i)
+		}
+		rows[i] = lineFrom(row)
+	}
+	td.content = strings.Join(rows, "\n")
+	return nil
+}
+
+// cellReplacer replaces the characters that separate cells and rows with spaces, so that e.g. an imported entry with
+// several paragraphs stays in one row.
+var cellReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+func lineFrom(row []string) string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = cellReplacer.Replace(cell)
+	}
+	return strings.Join(cells, "\t")
+}
+
+func (td *StringBasedTabularData) Empty(ctx context.Context) (bool, error) {
 	return td.content == "", nil
 }
 
 type TextFileLoader interface {
-	Upload(content string) error
-	Download() (string, error)
+	Upload(ctx context.Context, content string) error
+	Download(ctx context.Context) (string, error)
 }
 
 type TextFileBackedTabularData struct {
@@ -43,35 +75,61 @@ type TextFileBackedTabularData struct {
 	TextFileLoader TextFileLoader
 }
 
-func (td *TextFileBackedTabularData) AppendRow(row []string) error {
-	if e := td.cacheContent(); e != nil {
+func (td *TextFileBackedTabularData) AppendRow(ctx context.Context, row []string) error {
+	if e := td.cacheContent(ctx); e != nil {
 		return e
 	}
-	td.StringBasedTabularData.AppendRow(row)
-	e := td.TextFileLoader.Upload(td.content)
+	td.StringBasedTabularData.AppendRow(ctx, row)
+	e := td.TextFileLoader.Upload(ctx, td.content)
 	if e != nil {
 		return errors.Wrap(e, "Could not upload file content")
 	}
 	return nil
 }
-func (td *TextFileBackedTabularData) Rows() ([][]string, error) {
-	if e := td.cacheContent(); e != nil {
+func (td *TextFileBackedTabularData) AppendRows(ctx context.Context, rows [][]string) error {
+	if e := td.cacheContent(ctx); e != nil {
+		return e
+	}
+	td.StringBasedTabularData.AppendRows(ctx, rows)
+	e := td.TextFileLoader.Upload(ctx, td.content)
+	if e != nil {
+		return errors.Wrap(e, "Could not upload file content")
+	}
+	return nil
+}
+
+func (td *TextFileBackedTabularData) Rows(ctx context.Context) ([][]string, error) {
+	if e := td.cacheContent(ctx); e != nil {
 		return nil, e
 	}
-	return td.StringBasedTabularData.Rows()
+	return td.StringBasedTabularData.Rows(ctx)
+}
+
+func (td *TextFileBackedTabularData) UpdateRows(ctx context.Context, updates map[int][]string) error {
+	if e := td.cacheContent(ctx); e != nil {
+		return e
+	}
+	if e := td.StringBasedTabularData.UpdateRows(ctx, updates); e != nil {
+		return e
+	}
+	e := td.TextFileLoader.Upload(ctx, td.content)
+	if e != nil {
+		return errors.Wrap(e, "Could not upload file content")
+	}
+	return nil
 }
 
-func (td *TextFileBackedTabularData) Empty() (bool, error) {
-	if e := td.cacheContent(); e != nil {
+func (td *TextFileBackedTabularData) Empty(ctx context.Context) (bool, error) {
+	if e := td.cacheContent(ctx); e != nil {
 		return false, e
 	}
-	return td.StringBasedTabularData.Empty()
+	return td.StringBasedTabularData.Empty(ctx)
 }
 
-func (td *TextFileBackedTabularData) cacheContent() error {
+func (td *TextFileBackedTabularData) cacheContent(ctx context.Context) error {
 	if td.content == "" {
 		var e error
-		td.content, e = td.TextFileLoader.Download()
+		td.content, e = td.TextFileLoader.Download(ctx)
 		if e != nil {
 			return errors.Wrap(e, "Could not download file content")
 		}